@@ -0,0 +1,15 @@
+# Return the whole Pi-hole configuration
+data "pihole_config" "all" {}
+
+# Return the dns section only
+data "pihole_config" "dns" {
+  section = "dns"
+}
+
+output "local_domain" {
+  value = data.pihole_config.dns.values["dns.domain"]
+}
+
+output "upstreams" {
+  value = jsondecode(data.pihole_config.dns.values["dns.upstreams"])
+}
@@ -5,3 +5,9 @@ data "pihole_domains" "all" {}
 data "pihole_domains" "denied" {
   type = "deny"
 }
+
+# Return all denied regex domains registered with pihole
+data "pihole_domains" "denied_regex" {
+  type = "deny"
+  kind = "regex"
+}
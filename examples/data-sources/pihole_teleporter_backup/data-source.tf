@@ -0,0 +1,8 @@
+# Take a point-in-time backup of the Pi-hole configuration
+data "pihole_teleporter_backup" "backup" {
+  path = "${path.root}/backups/pihole-teleporter.zip"
+}
+
+output "backup_sha256" {
+  value = data.pihole_teleporter_backup.backup.sha256
+}
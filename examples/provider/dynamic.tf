@@ -27,8 +27,8 @@ resource "docker_container" "pihole" {
 }
 
 provider "pihole" {
-  url       = local.pihole_url
-  api_token = sha256(sha256(local.pihole_password))
+  url      = local.pihole_url
+  password = local.pihole_password
 }
 
 resource "null_resource" "pihole_wait" {
@@ -12,15 +12,16 @@ provider "pihole" {
 }
 
 provider "pihole" {
-  url = "https://pihole.domain.com" # PIHOLE_URL
+  url      = "https://pihole.domain.com" # PIHOLE_URL
+  password = var.pihole_password         # PIHOLE_PASSWORD
 
-  # Requires Pi-hole Web Interface >= 5.11.0
-  api_token = var.pihole_api_token # PIHOLE_API_TOKEN
+  # Required when two-factor authentication is enabled on the Pi-hole
+  totp_secret = var.pihole_totp_secret # PIHOLE_TOTP_SECRET
 }
 
 provider "pihole" {
-  url = "https://pihole.domain.com"
+  url = "https://pihole.domain.com" # PIHOLE_URL
 
-  # Pi-hole sets the API token to the admin password hashed twiced via SHA-256
-  api_token = sha256(sha256(var.pihole_password))
+  # Application password, changes require webserver.api.app_sudo to be enabled
+  api_token = var.pihole_app_password # PIHOLE_API_TOKEN
 }
@@ -1,3 +1,9 @@
 resource "pihole_ad_blocker_status" "status" {
   enabled = true
 }
+
+# Disable blocking for 10 minutes, e.g. during a migration window
+resource "pihole_ad_blocker_status" "migration" {
+  enabled = false
+  timer   = "10m"
+}
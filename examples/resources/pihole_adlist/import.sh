@@ -0,0 +1 @@
+terraform import pihole_adlist.stevenblack block/https://raw.githubusercontent.com/StevenBlack/hosts/master/hosts
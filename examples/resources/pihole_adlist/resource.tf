@@ -0,0 +1,10 @@
+resource "pihole_adlist" "stevenblack" {
+  address = "https://raw.githubusercontent.com/StevenBlack/hosts/master/hosts"
+  comment = "Unified hosts"
+}
+
+resource "pihole_adlist" "allowlist" {
+  address   = "https://example.com/allowlist.txt"
+  type      = "allow"
+  group_ids = [pihole_group.group.id]
+}
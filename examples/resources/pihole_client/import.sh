@@ -0,0 +1 @@
+terraform import pihole_client.laptop AA:BB:CC:DD:EE:FF
@@ -0,0 +1,10 @@
+resource "pihole_client" "laptop" {
+  client    = "aa:bb:cc:dd:ee:ff"
+  comment   = "Work laptop"
+  group_ids = [pihole_group.group.id]
+}
+
+resource "pihole_client" "iot" {
+  client    = "192.168.20.0/24"
+  group_ids = [pihole_group.group.id]
+}
@@ -2,3 +2,9 @@ resource "pihole_cname_record" "record" {
   domain = "foo.com"
   target = "bar.com"
 }
+
+resource "pihole_cname_record" "short_lived" {
+  domain = "baz.com"
+  target = "bar.com"
+  ttl    = 300
+}
@@ -0,0 +1 @@
+terraform import pihole_conditional_forwarder.lab 192.168.10.0/24
@@ -0,0 +1,11 @@
+resource "pihole_conditional_forwarder" "lab" {
+  cidr   = "192.168.10.0/24"
+  server = "192.168.10.1"
+  domain = "lab"
+}
+
+resource "pihole_conditional_forwarder" "corp" {
+  cidr   = "10.0.0.0/8"
+  server = "10.0.0.53#5353"
+  domain = "corp.example.com"
+}
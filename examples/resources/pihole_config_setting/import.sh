@@ -0,0 +1 @@
+terraform import pihole_config_setting.blocking_mode dns.blocking.mode
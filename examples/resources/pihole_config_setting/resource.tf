@@ -0,0 +1,9 @@
+resource "pihole_config_setting" "blocking_mode" {
+  path  = "dns.blocking.mode"
+  value = jsonencode("NULL")
+}
+
+resource "pihole_config_setting" "query_logging" {
+  path  = "dns.queryLogging"
+  value = jsonencode(false)
+}
@@ -0,0 +1 @@
+terraform import pihole_dhcp_settings.dhcp dhcp-settings
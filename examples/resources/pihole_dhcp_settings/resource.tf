@@ -0,0 +1,8 @@
+resource "pihole_dhcp_settings" "dhcp" {
+  active     = true
+  start      = "192.168.1.100"
+  end        = "192.168.1.200"
+  router     = "192.168.1.1"
+  netmask    = "255.255.255.0"
+  lease_time = "24h"
+}
@@ -0,0 +1 @@
+terraform import pihole_dhcp_static_lease.printer AA:BB:CC:DD:EE:FF
@@ -0,0 +1,5 @@
+resource "pihole_dhcp_static_lease" "printer" {
+  mac      = "aa:bb:cc:dd:ee:ff"
+  ip       = "192.168.1.20"
+  hostname = "printer"
+}
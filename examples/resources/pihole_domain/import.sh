@@ -0,0 +1 @@
+terraform import pihole_domain.ads 1
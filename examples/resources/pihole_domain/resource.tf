@@ -0,0 +1,12 @@
+resource "pihole_domain" "ads" {
+  domain  = "ads.example.com"
+  type    = "deny"
+  comment = "Block ads"
+}
+
+resource "pihole_domain" "tracking" {
+  domain    = "(\\.|^)tracking\\.example\\.com$"
+  type      = "deny"
+  kind      = "regex"
+  group_ids = [pihole_group.group.id]
+}
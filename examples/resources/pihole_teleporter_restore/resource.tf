@@ -0,0 +1,15 @@
+# Restore everything contained in the archive
+resource "pihole_teleporter_restore" "full" {
+  path = "${path.module}/backups/pihole-teleporter.zip"
+}
+
+# Only restore the groups and adlists of the gravity database
+resource "pihole_teleporter_restore" "adlists" {
+  path = "${path.module}/backups/pihole-teleporter.zip"
+
+  import {
+    config         = false
+    dhcp_leases    = false
+    gravity_tables = ["group", "adlist", "adlist_by_group"]
+  }
+}
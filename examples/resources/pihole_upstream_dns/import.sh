@@ -0,0 +1 @@
+terraform import pihole_upstream_dns.upstreams upstream-dns
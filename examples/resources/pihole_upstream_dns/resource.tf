@@ -0,0 +1,7 @@
+resource "pihole_upstream_dns" "upstreams" {
+  upstreams = [
+    "127.0.0.1#5335",
+    "9.9.9.9",
+    "2620:fe::fe",
+  ]
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"time"
 )
 
 type EnableAdBlockResponse struct {
@@ -20,14 +21,37 @@ func (eb EnableAdBlockResponse) ToEnableAdBlock() *EnableAdBlock {
 
 type EnableAdBlock struct {
 	Enabled bool
+	// Timer is the time remaining until the blocking status is toggled back, zero when no timer is running
+	Timer time.Duration
 }
 
-// GetAdBlockerStatus returns whether pihole ad blocking is enabled or not
-func (c Client) GetAdBlockerStatus(ctx context.Context) (*EnableAdBlock, error) {
-	if c.tokenClient != nil {
-		return nil, fmt.Errorf("%w: set ad blocker status", ErrNotImplementedTokenClient)
+type blockingResponse struct {
+	Blocking string   `json:"blocking"`
+	Timer    *float64 `json:"timer"`
+}
+
+// toEnableAdBlock converts a blocking response into an EnableAdBlock object
+func (br blockingResponse) toEnableAdBlock() (*EnableAdBlock, error) {
+	var enabled bool
+	switch br.Blocking {
+	case "disabled":
+		enabled = false
+	case "enabled":
+		enabled = true
+	default:
+		return nil, fmt.Errorf("got unexpected value, blocking=%s", br.Blocking)
 	}
 
+	var timer time.Duration
+	if br.Timer != nil {
+		timer = time.Duration(*br.Timer * float64(time.Second))
+	}
+
+	return &EnableAdBlock{Enabled: enabled, Timer: timer}, nil
+}
+
+// GetAdBlockerStatus returns whether pihole ad blocking is enabled or not
+func (c Client) GetAdBlockerStatus(ctx context.Context) (*EnableAdBlock, error) {
 	req, err := c.RequestWithSession2(ctx, "GET", "/api/dns/blocking", map[string]any{})
 	if err != nil {
 		return nil, err
@@ -38,47 +62,38 @@ func (c Client) GetAdBlockerStatus(ctx context.Context) (*EnableAdBlock, error)
 		return nil, err
 	}
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to retrieve current status, got status code %d", res.StatusCode)
+		return nil, fmt.Errorf("failed to retrieve current status: %w", newAPIError(res))
 	}
 
 	defer res.Body.Close()
-	type Response struct {
-		Blocking string `json:"blocking"`
-	}
 	b, err := io.ReadAll(res.Body)
 	if err != nil {
 		return nil, err
 	}
-	var response Response
+	var response blockingResponse
 	if err := json.Unmarshal(b, &response); err != nil {
 		return nil, err
 	}
 
-	var enabled bool
-	switch response.Blocking {
-	case "disabled":
-		enabled = false
-	case "enabled":
-		enabled = true
-	default:
-		return nil, fmt.Errorf("got unexpected value, blocking=%s", response.Blocking)
-	}
-	if response.Blocking == "disabled" {
-		enabled = false
-	}
-
-	return &EnableAdBlock{Enabled: enabled}, nil
+	return response.toEnableAdBlock()
 }
 
-// SetAdBlockEnabled sets whether pihole ad blocking is enabled or not
-func (c Client) SetAdBlockEnabled(ctx context.Context, enable bool) (*EnableAdBlock, error) {
-	if c.tokenClient != nil {
-		return nil, fmt.Errorf("%w: set ad blocker status", ErrNotImplementedTokenClient)
+// SetAdBlockEnabled sets whether pihole ad blocking is enabled or not. When timer is non-zero, Pi-hole
+// toggles the blocking status back once the timer elapses.
+func (c Client) SetAdBlockEnabled(ctx context.Context, enable bool, timer time.Duration) (*EnableAdBlock, error) {
+	if timer < 0 {
+		return nil, fmt.Errorf("blocking timer must not be negative, got %s", timer)
 	}
 
-	req, err := c.RequestWithSession2(ctx, "POST", "/api/dns/blocking", map[string]any{
+	data := map[string]any{
 		"blocking": enable,
-	})
+		"timer":    nil,
+	}
+	if timer > 0 {
+		data["timer"] = timer.Seconds()
+	}
+
+	req, err := c.RequestWithSession2(ctx, "POST", "/api/dns/blocking", data)
 	if err != nil {
 		return nil, err
 	}
@@ -88,31 +103,18 @@ func (c Client) SetAdBlockEnabled(ctx context.Context, enable bool) (*EnableAdBl
 		return nil, err
 	}
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to enable/disable blocking, got status code %d", res.StatusCode)
+		return nil, fmt.Errorf("failed to enable/disable blocking: %w", newAPIError(res))
 	}
 
 	defer res.Body.Close()
-	type Response struct {
-		Blocking string `json:"blocking"`
-	}
 	b, err := io.ReadAll(res.Body)
 	if err != nil {
 		return nil, err
 	}
-	var response Response
+	var response blockingResponse
 	if err := json.Unmarshal(b, &response); err != nil {
 		return nil, err
 	}
 
-	var enabled bool
-	switch response.Blocking {
-	case "disabled":
-		enabled = false
-	case "enabled":
-		enabled = true
-	default:
-		return nil, fmt.Errorf("got unexpected value, blocking=%s", response.Blocking)
-	}
-
-	return &EnableAdBlock{Enabled: enabled}, nil
+	return response.toEnableAdBlock()
 }
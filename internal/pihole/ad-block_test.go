@@ -0,0 +1,93 @@
+package pihole
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole/fakepihole"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdBlocker(t *testing.T) {
+	newBlockingClient := func(t *testing.T) *Client {
+		server := fakepihole.New(fakepihole.Config{})
+		t.Cleanup(server.Close)
+
+		client := New(Config{
+			Password: fakepihole.DefaultPassword,
+			URL:      server.URL,
+		})
+		require.NoError(t, client.Init(context.Background()))
+
+		return client
+	}
+
+	t.Run("Toggle blocking without a timer", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		client := newBlockingClient(t)
+
+		status, err := client.SetAdBlockEnabled(ctx, false, 0)
+		require.NoError(t, err)
+		require.False(t, status.Enabled)
+		require.Zero(t, status.Timer)
+
+		status, err = client.GetAdBlockerStatus(ctx)
+		require.NoError(t, err)
+		require.False(t, status.Enabled)
+		require.Zero(t, status.Timer)
+
+		status, err = client.SetAdBlockEnabled(ctx, true, 0)
+		require.NoError(t, err)
+		require.True(t, status.Enabled)
+	})
+
+	t.Run("Report the remaining timer", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		client := newBlockingClient(t)
+
+		status, err := client.SetAdBlockEnabled(ctx, false, 10*time.Minute)
+		require.NoError(t, err)
+		require.False(t, status.Enabled)
+		require.InDelta(t, float64(10*time.Minute), float64(status.Timer), float64(time.Second))
+
+		status, err = client.GetAdBlockerStatus(ctx)
+		require.NoError(t, err)
+		require.False(t, status.Enabled)
+		require.InDelta(t, float64(10*time.Minute), float64(status.Timer), float64(time.Second))
+
+		// Setting the status again without a timer cancels the running one
+		status, err = client.SetAdBlockEnabled(ctx, false, 0)
+		require.NoError(t, err)
+		require.False(t, status.Enabled)
+		require.Zero(t, status.Timer)
+	})
+
+	t.Run("Revert the status once the timer expires", func(t *testing.T) {
+		t.Parallel()
+
+		ctx := context.Background()
+		client := newBlockingClient(t)
+
+		_, err := client.SetAdBlockEnabled(ctx, false, 50*time.Millisecond)
+		require.NoError(t, err)
+
+		require.Eventually(t, func() bool {
+			status, err := client.GetAdBlockerStatus(ctx)
+			return err == nil && status.Enabled && status.Timer == 0
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("Fail with a negative timer", func(t *testing.T) {
+		t.Parallel()
+
+		client := newBlockingClient(t)
+
+		_, err := client.SetAdBlockEnabled(context.Background(), false, -time.Second)
+		require.ErrorContains(t, err, "must not be negative")
+	})
+}
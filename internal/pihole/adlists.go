@@ -0,0 +1,280 @@
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"time"
+)
+
+const (
+	// AdlistTypeBlock is an adlist whose domains are blocked
+	AdlistTypeBlock string = "block"
+	// AdlistTypeAllow is an adlist whose domains are allowed
+	AdlistTypeAllow string = "allow"
+)
+
+type AdlistResponseList struct {
+	Lists     []AdlistResponse `json:"lists"`
+	Processed *struct {
+		Errors []struct {
+			Item    string `json:"item"`
+			Message string `json:"error"`
+		} `json:"errors"`
+	} `json:"processed"`
+}
+
+type AdlistResponse struct {
+	ID           int64   `json:"id"`
+	Address      string  `json:"address"`
+	Type         string  `json:"type"`
+	Comment      *string `json:"comment"`
+	Groups       []int64 `json:"groups"`
+	Enabled      bool    `json:"enabled"`
+	DateAdded    int64   `json:"date_added"`
+	DateModified int64   `json:"date_modified"`
+}
+
+type Adlist struct {
+	ID           int64
+	Address      string
+	Type         string
+	Comment      string
+	Enabled      bool
+	DateAdded    time.Time
+	DateModified time.Time
+	GroupIDs     []int64
+}
+
+type AdlistList []*Adlist
+
+type AdlistCreateRequest struct {
+	Address  string
+	Type     string
+	Comment  string
+	Enabled  *bool
+	GroupIDs []int64
+}
+
+type AdlistUpdateRequest struct {
+	Address  string
+	Type     string
+	Comment  string
+	Enabled  *bool
+	GroupIDs []int64
+}
+
+// ToAdlistList converts an AdlistResponseList to an AdlistList
+func (l AdlistResponseList) ToAdlistList() AdlistList {
+	list := make(AdlistList, len(l.Lists))
+
+	for i, a := range l.Lists {
+		list[i] = a.ToAdlist()
+	}
+
+	return list
+}
+
+// ToAdlist converts an AdlistResponse to an Adlist
+func (a AdlistResponse) ToAdlist() *Adlist {
+	comment := ""
+	if a.Comment != nil {
+		comment = *a.Comment
+	}
+
+	return &Adlist{
+		ID:           a.ID,
+		Address:      a.Address,
+		Type:         a.Type,
+		Comment:      comment,
+		Enabled:      a.Enabled,
+		DateAdded:    time.Unix(a.DateAdded, 0),
+		DateModified: time.Unix(a.DateModified, 0),
+		GroupIDs:     a.Groups,
+	}
+}
+
+// validAdlistType indicates whether the passed adlist type is supported
+func validAdlistType(adlistType string) bool {
+	return adlistType == AdlistTypeBlock || adlistType == AdlistTypeAllow
+}
+
+// adlistPath returns the API path for the passed adlist address and type
+func adlistPath(address string, adlistType string) string {
+	path := "/api/lists"
+	if address != "" {
+		path = fmt.Sprintf("%s/%s", path, url.PathEscape(address))
+	}
+
+	if adlistType == "" {
+		return path
+	}
+
+	return fmt.Sprintf("%s?%s", path, url.Values{"type": []string{adlistType}}.Encode())
+}
+
+// ListAdlists returns the list of gravity DB adlists
+func (c Client) ListAdlists(ctx context.Context) (AdlistList, error) {
+	req, err := c.RequestWithSession2(ctx, "GET", adlistPath("", ""), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to retrieve adlists: %w", newAPIError(res))
+	}
+
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	var response AdlistResponseList
+	if err := json.Unmarshal(b, &response); err != nil {
+		return nil, err
+	}
+
+	return response.ToAdlistList(), nil
+}
+
+// GetAdlist returns a Pi-hole adlist by address and type, the same address can be both a blocklist and an allowlist
+func (c Client) GetAdlist(ctx context.Context, address string, adlistType string) (*Adlist, error) {
+	adlists, err := c.ListAdlists(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range adlists {
+		if a.Address == address && a.Type == adlistType {
+			return a, nil
+		}
+	}
+
+	return nil, NewNotFoundError(fmt.Sprintf("%s adlist with address %q not found", adlistType, address))
+}
+
+// CreateAdlist creates an adlist with the passed attributes
+func (c Client) CreateAdlist(ctx context.Context, ar *AdlistCreateRequest) (*Adlist, error) {
+	if !validAdlistType(ar.Type) {
+		return nil, fmt.Errorf("unknown adlist type: %s", ar.Type)
+	}
+
+	data := map[string]any{
+		"address": ar.Address,
+		"comment": ar.Comment,
+	}
+	if ar.Enabled != nil {
+		data["enabled"] = *ar.Enabled
+	}
+	if ar.GroupIDs != nil {
+		data["groups"] = ar.GroupIDs
+	}
+
+	req, err := c.RequestWithSession2(ctx, "POST", adlistPath("", ar.Type), data)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != 201 {
+		return nil, fmt.Errorf("failed to create adlist: %w", newAPIError(res))
+	}
+
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	var response AdlistResponseList
+	if err := json.Unmarshal(b, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Processed != nil && len(response.Processed.Errors) > 0 {
+		return nil, fmt.Errorf("failed to create adlist %q: %s", ar.Address, response.Processed.Errors[0].Message)
+	}
+
+	if len(response.Lists) != 1 {
+		return nil, fmt.Errorf("failed to create adlist %q, got %d adlists in response", ar.Address, len(response.Lists))
+	}
+
+	return response.Lists[0].ToAdlist(), nil
+}
+
+// UpdateAdlist updates the comment, enabled state and groups of an adlist
+func (c Client) UpdateAdlist(ctx context.Context, ar *AdlistUpdateRequest) (*Adlist, error) {
+	if !validAdlistType(ar.Type) {
+		return nil, fmt.Errorf("unknown adlist type: %s", ar.Type)
+	}
+
+	data := map[string]any{
+		"type":    ar.Type,
+		"comment": ar.Comment,
+	}
+	if ar.Enabled != nil {
+		data["enabled"] = *ar.Enabled
+	}
+	if ar.GroupIDs != nil {
+		data["groups"] = ar.GroupIDs
+	}
+
+	req, err := c.RequestWithSession2(ctx, "PUT", adlistPath(ar.Address, ar.Type), data)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to update adlist: %w", newAPIError(res))
+	}
+
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	var response AdlistResponseList
+	if err := json.Unmarshal(b, &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.Lists) != 1 {
+		return nil, fmt.Errorf("failed to update adlist %q, got %d adlists in response", ar.Address, len(response.Lists))
+	}
+
+	return response.Lists[0].ToAdlist(), nil
+}
+
+// DeleteAdlist deletes an adlist
+func (c Client) DeleteAdlist(ctx context.Context, address string, adlistType string) error {
+	if !validAdlistType(adlistType) {
+		return fmt.Errorf("unknown adlist type: %s", adlistType)
+	}
+
+	req, err := c.RequestWithSession2(ctx, "DELETE", adlistPath(address, adlistType), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != 204 {
+		return fmt.Errorf("failed to delete adlist: %w", newAPIError(res))
+	}
+
+	return nil
+}
@@ -0,0 +1,49 @@
+package pihole
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetAdlist(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"session":{"valid":true,"sid":"sid","csrf":"csrf","validity":300}}`)) //nolint:errcheck
+	})
+	mux.HandleFunc("GET /api/lists", func(w http.ResponseWriter, r *http.Request) {
+		// The same address is both a blocklist and an allowlist
+		w.Write([]byte(`{"lists":[{"id":1,"address":"https://example.com/list.txt","type":"block","comment":"blocked","enabled":true,"groups":[0]},{"id":2,"address":"https://example.com/list.txt","type":"allow","comment":"allowed","enabled":false,"groups":[0]}]}`)) //nolint:errcheck
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	t.Run("Match the address and type of the list", func(t *testing.T) {
+		t.Parallel()
+
+		client := New(Config{Password: "test", URL: server.URL})
+
+		blocklist, err := client.GetAdlist(context.Background(), "https://example.com/list.txt", AdlistTypeBlock)
+		require.NoError(t, err)
+		require.Equal(t, int64(1), blocklist.ID)
+		require.Equal(t, "blocked", blocklist.Comment)
+
+		allowlist, err := client.GetAdlist(context.Background(), "https://example.com/list.txt", AdlistTypeAllow)
+		require.NoError(t, err)
+		require.Equal(t, int64(2), allowlist.ID)
+		require.Equal(t, "allowed", allowlist.Comment)
+	})
+
+	t.Run("Fail if no list matches", func(t *testing.T) {
+		t.Parallel()
+
+		client := New(Config{Password: "test", URL: server.URL})
+
+		_, err := client.GetAdlist(context.Background(), "https://example.com/other.txt", AdlistTypeBlock)
+		require.ErrorAs(t, err, new(*NotFoundError))
+	})
+}
@@ -7,14 +7,15 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
-	"math/rand/v2"
+	"mime/multipart"
 	"net/http"
 	"net/url"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/iolave/go-proxmox/pkg/cloudflare"
-	pihole "github.com/ryanwholey/go-pihole"
 )
 
 type Config struct {
@@ -23,19 +24,32 @@ type Config struct {
 	UserAgent      string
 	Client         *http.Client
 	APIToken       string
+	TOTPSecret     string
 	CFServiceToken *cloudflare.ServiceToken
+	// MaxRetries is the number of times a request rejected with 429, 502, 503 or 504 is retried, 0 disables retries
+	MaxRetries int
+	// RetryMaxWait caps the wait between two attempts, DefaultRetryMaxWait is used when not set
+	RetryMaxWait time.Duration
+	// RequestsPerSecond limits the rate of requests sent to Pi-hole, 0 disables the limit
+	RequestsPerSecond float64
+	// Burst is the number of requests which can be sent at once before RequestsPerSecond applies
+	Burst int
 }
 
 type Client struct {
 	URL            string
 	UserAgent      string
 	password       string
-	sessionID      string
-	sessionToken   string
+	session        *session
 	webPassword    string
+	totpSecret     string
+	apiToken       string
 	client         *http.Client
-	tokenClient    *pihole.Client
 	cfServiceToken *cloudflare.ServiceToken
+
+	// dnsMu serializes the changes of the dns.hosts and dns.cnameRecords arrays, some of which read and write back
+	// the whole array
+	dnsMu *sync.Mutex
 }
 
 // doubleHash256 takes a string, double hashes it using the sha256 algorithm and returns the value
@@ -53,23 +67,48 @@ func New(config Config) *Client {
 		URL:            config.URL,
 		UserAgent:      config.UserAgent,
 		password:       config.Password,
-		client:         config.Client,
+		session:        &session{now: time.Now},
 		webPassword:    doubleHash256(config.Password),
+		totpSecret:     config.TOTPSecret,
+		apiToken:       config.APIToken,
 		cfServiceToken: config.CFServiceToken,
+		dnsMu:          &sync.Mutex{},
+	}
+
+	httpClient := &http.Client{}
+	if config.Client != nil {
+		// Copy the client so that wrapping its transport does not affect the caller
+		c := *config.Client
+		httpClient = &c
 	}
 
-	if client.client == nil {
-		client.client = &http.Client{}
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
 	}
 
-	if config.APIToken != "" {
-		client.tokenClient = pihole.New(pihole.Config{
-			BaseURL:    config.URL,
-			APIToken:   config.APIToken,
-			HttpClient: client.client,
-		})
+	// Rate limit below the retries so that every attempt waits for the limiter
+	if config.RequestsPerSecond > 0 {
+		base = &rateLimitTransport{
+			base:    base,
+			limiter: newRateLimiter(config.RequestsPerSecond, config.Burst),
+		}
 	}
 
+	if config.MaxRetries > 0 {
+		base = &retryTransport{
+			base:       base,
+			maxRetries: config.MaxRetries,
+			maxWait:    config.RetryMaxWait,
+		}
+	}
+
+	httpClient.Transport = &sessionTransport{
+		base:   base,
+		client: client,
+	}
+	client.client = httpClient
+
 	return client
 }
 
@@ -79,7 +118,7 @@ func (c *Client) Init(ctx context.Context) error {
 		return fmt.Errorf("%w: Pi-hole URL is not set", ErrClientValidationFailed)
 	}
 
-	if c.tokenClient != nil {
+	if c.apiToken != "" {
 		return nil
 	}
 
@@ -91,26 +130,65 @@ func (c *Client) Init(ctx context.Context) error {
 		return fmt.Errorf("%w: webPassword is not set", ErrClientValidationFailed)
 	}
 
+	if c.totpSecret != "" {
+		if _, err := decodeTOTPSecret(c.totpSecret); err != nil {
+			return fmt.Errorf("%w: %s", ErrClientValidationFailed, err)
+		}
+	}
+
 	return nil
 }
 
-// Login creates a session and sets the proper attributes on the client for session based requests (not api token reqeuests)
+// Login creates a session and sets the proper attributes on the client for session based requests.
+// When authenticating with an application password, it also checks whether the session is allowed to write.
 func (c *Client) Login(ctx context.Context) error {
+	c.session.loginMu.Lock()
+	defer c.session.loginMu.Unlock()
+
+	return c.startSession(ctx)
+}
+
+// startSession logs in and replaces the shared session, the caller must hold the session login lock
+func (c Client) startSession(ctx context.Context) error {
 	if err := c.login(ctx); err != nil {
-		return fmt.Errorf("%w: %s", ErrLoginFailed, err)
+		return fmt.Errorf("%w: %w", ErrLoginFailed, err)
 	}
 
-	if c.sessionToken == "" {
+	id, csrf := c.session.credentials()
+
+	if csrf == "" {
 		return fmt.Errorf("%w: token not set", ErrClientValidationFailed)
 	}
 
-	if c.sessionID == "" {
+	if id == "" {
 		return fmt.Errorf("%w: sessionID not set", ErrClientValidationFailed)
 	}
 
+	if c.apiToken != "" {
+		// Application password sessions are read-only unless webserver.api.app_sudo is enabled
+		c.session.setAppSudo(true)
+
+		// The login lock is held, a rejected session must not trigger another login
+		value, err := c.GetConfigValue(context.WithValue(ctx, sessionRetryKey{}, true), "webserver.api.app_sudo")
+		if err != nil {
+			return fmt.Errorf("%w: failed to read webserver.api.app_sudo: %w", ErrLoginFailed, err)
+		}
+
+		c.session.setAppSudo(string(value) == "true")
+	}
+
 	return nil
 }
 
+// checkAppSudo returns ErrAppSudoDisabled for write requests of application password sessions without app_sudo
+func (c Client) checkAppSudo(method string, path string) error {
+	if c.apiToken == "" || c.session.canWrite() || method == http.MethodGet {
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s %s", ErrAppSudoDisabled, method, path)
+}
+
 // Request executes a basic unauthenticated http request
 func (c *Client) Request(ctx context.Context, method string, path string, data *url.Values) (*http.Request, error) {
 	d := data
@@ -136,37 +214,30 @@ func (c *Client) Request(ctx context.Context, method string, path string, data *
 	return req, nil
 }
 
-// mergeURLValues merges the passed URL values into a single url.Values object
-func mergeURLValues(vs ...url.Values) url.Values {
-	data := url.Values{}
-
-	for _, val := range vs {
-		for k, v := range val {
-			data.Add(k, v[0])
-		}
+// RequestWithSession2 executes a request with appropriate session authentication
+func (c Client) RequestWithSession2(ctx context.Context, method string, path string, data map[string]any) (*http.Request, error) {
+	if err := c.ensureSession(ctx); err != nil {
+		return nil, err
 	}
 
-	return data
-}
+	if err := c.checkAppSudo(method, path); err != nil {
+		return nil, err
+	}
 
-// RequestWithSession executes a request with appropriate session authentication
-func (c Client) RequestWithSession(ctx context.Context, method string, path string, data *url.Values) (*http.Request, error) {
-	if c.sessionToken == "" || c.sessionID == "" {
-		if err := c.Login(ctx); err != nil {
-			return nil, err
-		}
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
 	}
 
-	d := mergeURLValues(url.Values{
-		"token": []string{c.sessionToken},
-	}, *data)
-	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s%s", c.URL, path), strings.NewReader(d.Encode()))
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s%s", c.URL, path), bytes.NewBuffer(b))
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("content-type", "application/x-www-form-urlencoded")
-	req.Header.Add("cookie", fmt.Sprintf("PHPSESSID=%s", c.sessionID))
+	req.Header.Add("content-type", "application/json")
+	id, csrf := c.session.credentials()
+	req.Header.Add("X-FTL-SID", id)
+	req.Header.Add("X-FTL-CSRF", csrf)
 
 	if c.cfServiceToken == nil {
 		return req, nil
@@ -179,27 +250,62 @@ func (c Client) RequestWithSession(ctx context.Context, method string, path stri
 	return req, nil
 }
 
-// RequestWithSession2 executes a request with appropriate session authentication
-func (c Client) RequestWithSession2(ctx context.Context, method string, path string, data map[string]any) (*http.Request, error) {
-	if c.sessionToken == "" || c.sessionID == "" {
-		if err := c.Login(ctx); err != nil {
+// MultipartFile is a file part of a multipart/form-data request body
+type MultipartFile struct {
+	Field    string
+	Filename string
+	Data     []byte
+}
+
+// RequestWithSessionMultipart executes a request with appropriate session authentication and a multipart/form-data body
+func (c Client) RequestWithSessionMultipart(ctx context.Context, method string, path string, fields map[string]string, files []MultipartFile) (*http.Request, error) {
+	if err := c.ensureSession(ctx); err != nil {
+		return nil, err
+	}
+
+	if err := c.checkAppSudo(method, path); err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	w := multipart.NewWriter(&body)
+
+	names := make([]string, 0, len(fields))
+	for k := range fields {
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	for _, k := range names {
+		if err := w.WriteField(k, fields[k]); err != nil {
 			return nil, err
 		}
 	}
 
-	b, err := json.Marshal(data)
-	if err != nil {
+	for _, f := range files {
+		part, err := w.CreateFormFile(f.Field, f.Filename)
+		if err != nil {
+			return nil, err
+		}
+
+		if _, err := part.Write(f.Data); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := w.Close(); err != nil {
 		return nil, err
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s%s", c.URL, path), bytes.NewBuffer(b))
+	req, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s%s", c.URL, path), &body)
 	if err != nil {
 		return nil, err
 	}
 
-	req.Header.Add("content-type", "application/json")
-	req.Header.Add("X-FTL-SID", c.sessionID)
-	req.Header.Add("X-FTL-CSRF", c.sessionToken)
+	req.Header.Add("content-type", w.FormDataContentType())
+	id, csrf := c.session.credentials()
+	req.Header.Add("X-FTL-SID", id)
+	req.Header.Add("X-FTL-CSRF", csrf)
 
 	if c.cfServiceToken == nil {
 		return req, nil
@@ -209,8 +315,6 @@ func (c Client) RequestWithSession2(ctx context.Context, method string, path str
 		return nil, err
 	}
 
-	time.Sleep(time.Second * time.Duration(rand.Int64N(60)))
-
 	return req, nil
 }
 
@@ -246,11 +350,32 @@ func (c Client) RequestWithAuth(ctx context.Context, method string, path string,
 	return req, nil
 }
 
-// login sets a new sessionID and csrf token in the client to be used for logged in requests
+// login creates a new session to be used for logged in requests
 func (c *Client) login(ctx context.Context) error {
+	password := c.password
+	if c.apiToken != "" {
+		password = c.apiToken
+	}
+
 	data := map[string]any{
-		"password": c.password,
+		"password": password,
+	}
+
+	if c.totpSecret != "" {
+		key, err := decodeTOTPSecret(c.totpSecret)
+		if err != nil {
+			return err
+		}
+
+		t, err := nextTOTPTime(ctx, c.session.now, c.session.totpStep)
+		if err != nil {
+			return err
+		}
+
+		c.session.totpStep = totpStep(t)
+		data["totp"] = totpCode(key, t, totpDigits)
 	}
+
 	b, _ := json.Marshal(data)
 
 	req, err := http.NewRequestWithContext(ctx, "POST", fmt.Sprintf("%s%s", c.URL, "/api/auth"), bytes.NewBuffer(b))
@@ -277,10 +402,6 @@ func (c *Client) login(ctx context.Context) error {
 		return fmt.Errorf("failed to read req body: %s", err)
 	}
 
-	if res.StatusCode != http.StatusOK {
-		return fmt.Errorf("failed to login, got status code: %d", res.StatusCode)
-	}
-
 	type Response struct {
 		Session struct {
 			Valid    bool   `json:"valid"`
@@ -290,15 +411,34 @@ func (c *Client) login(ctx context.Context) error {
 			Validity int    `json:"validity"`
 			Message  string `json:"message"`
 		} `json:"session"`
+		Error struct {
+			Key     string `json:"key"`
+			Message string `json:"message"`
+		} `json:"error"`
 	}
 
 	var responseResult Response
+	if res.StatusCode != http.StatusOK {
+		// Pi-hole rejects logins without a TOTP code when two-factor authentication is enabled
+		if err := json.Unmarshal(b, &responseResult); err == nil && c.totpSecret == "" &&
+			(responseResult.Session.TOTP || strings.Contains(responseResult.Error.Message, "2FA")) {
+			return fmt.Errorf("two-factor authentication is enabled on the Pi-hole but no TOTP secret is configured")
+		}
+
+		// Rejected passwords are described by the session message rather than an error object
+		apiErr := parseAPIError(res, b)
+		if apiErr.Key == "" && responseResult.Session.Message != "" {
+			apiErr.Message = responseResult.Session.Message
+		}
+
+		return fmt.Errorf("failed to login: %w", apiErr)
+	}
+
 	if err := json.Unmarshal(b, &responseResult); err != nil {
 		return fmt.Errorf("unable to parse login response: %s", err)
 	}
 
-	c.sessionID = responseResult.Session.SID
-	c.sessionToken = responseResult.Session.CSRF
+	c.session.set(responseResult.Session.SID, responseResult.Session.CSRF, time.Duration(responseResult.Session.Validity)*time.Second)
 	return nil
 }
 
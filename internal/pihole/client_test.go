@@ -2,10 +2,16 @@ package pihole
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole/fakepihole"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -38,13 +44,95 @@ func TestClient(t *testing.T) {
 		require.Contains(t, err.Error(), "request failed")
 	})
 
+	t.Run("Fail login if the password is incorrect", func(t *testing.T) {
+		t.Parallel()
+
+		server := fakepihole.New(fakepihole.Config{})
+		defer server.Close()
+
+		client := New(Config{
+			Password: "wrong",
+			URL:      server.URL,
+		})
+
+		err := client.Init(context.Background())
+		require.NoError(t, err)
+
+		err = client.Login(context.Background())
+		require.ErrorIs(t, err, ErrLoginFailed)
+		require.Contains(t, err.Error(), "password incorrect")
+
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+	})
+
+	t.Run("Describe a rejected login by the session message", func(t *testing.T) {
+		t.Parallel()
+
+		mux := http.NewServeMux()
+
+		mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"session":{"valid":false,"totp":false,"sid":null,"validity":-1,"message":"password incorrect"},"took":0.1}`)) //nolint:errcheck
+		})
+
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		client := New(Config{
+			Password: "wrong",
+			URL:      server.URL,
+		})
+
+		require.NoError(t, client.Init(context.Background()))
+
+		err := client.Login(context.Background())
+		require.ErrorIs(t, err, ErrLoginFailed)
+
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+		require.Empty(t, apiErr.Key)
+		require.Equal(t, "password incorrect", apiErr.Message)
+	})
+
+	t.Run("Prefer the error object over the session message", func(t *testing.T) {
+		t.Parallel()
+
+		mux := http.NewServeMux()
+
+		mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error":{"key":"rate_limiting","message":"Rate-limiting login attempts","hint":null},"session":{"message":"password incorrect"}}`)) //nolint:errcheck
+		})
+
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		client := New(Config{
+			Password: "test",
+			URL:      server.URL,
+		})
+
+		require.NoError(t, client.Init(context.Background()))
+
+		err := client.Login(context.Background())
+		require.ErrorIs(t, err, ErrLoginFailed)
+
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, "rate_limiting", apiErr.Key)
+		require.Equal(t, "Rate-limiting login attempts", apiErr.Message)
+	})
+
 	t.Run("Fail login if no session ID is found", func(t *testing.T) {
 		t.Parallel()
 
 		mux := http.NewServeMux()
 
-		mux.HandleFunc("/admin/index.php", func(w http.ResponseWriter, r *http.Request) {
-			w.Write([]byte(`<div id="token">token</div>`)) //nolint:errcheck
+		mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"session":{"valid":true,"sid":null,"csrf":"csrf","validity":300}}`)) //nolint:errcheck
 		})
 
 		server := httptest.NewServer(mux)
@@ -59,18 +147,17 @@ func TestClient(t *testing.T) {
 		require.NoError(t, err)
 
 		err = client.Login(context.Background())
-		require.ErrorIs(t, err, ErrLoginFailed)
-		require.Contains(t, err.Error(), "session ID not found")
+		require.ErrorIs(t, err, ErrClientValidationFailed)
+		require.Contains(t, err.Error(), "sessionID not set")
 	})
 
-	t.Run("Fail login if session ID is malformed", func(t *testing.T) {
+	t.Run("Fail login if the login response is malformed", func(t *testing.T) {
 		t.Parallel()
 
 		mux := http.NewServeMux()
 
-		mux.HandleFunc("/admin/index.php", func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Set-Cookie", "malformed")
-			w.Write([]byte(`<div id="token">token</div>`)) //nolint:errcheck
+		mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`<html>Pi-hole</html>`)) //nolint:errcheck
 		})
 
 		server := httptest.NewServer(mux)
@@ -86,7 +173,7 @@ func TestClient(t *testing.T) {
 
 		err = client.Login(context.Background())
 		require.ErrorIs(t, err, ErrLoginFailed)
-		require.Contains(t, err.Error(), "malformed session cookie")
+		require.Contains(t, err.Error(), "unable to parse login response")
 	})
 
 	t.Run("Fail login if token is not found in response", func(t *testing.T) {
@@ -94,9 +181,8 @@ func TestClient(t *testing.T) {
 
 		mux := http.NewServeMux()
 
-		mux.HandleFunc("/admin/index.php", func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Set-Cookie", "session-id=ID;")
-			w.Write([]byte(``)) //nolint:errcheck
+		mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"session":{"valid":true,"sid":"sid","csrf":null,"validity":300}}`)) //nolint:errcheck
 		})
 
 		server := httptest.NewServer(mux)
@@ -111,18 +197,172 @@ func TestClient(t *testing.T) {
 		require.NoError(t, err)
 
 		err = client.Login(context.Background())
-		require.ErrorIs(t, err, ErrLoginFailed)
-		require.Contains(t, err.Error(), "invalid password")
+		require.ErrorIs(t, err, ErrClientValidationFailed)
+		require.Contains(t, err.Error(), "token not set")
 	})
 
 	t.Run("Initialize a client", func(t *testing.T) {
 		t.Parallel()
 
+		server := fakepihole.New(fakepihole.Config{})
+		defer server.Close()
+
+		client := New(Config{
+			Password: fakepihole.DefaultPassword,
+			URL:      server.URL,
+		})
+
+		require.NoError(t, client.Init(context.Background()))
+		require.Equal(t, client.password, fakepihole.DefaultPassword)
+		require.Equal(t, client.webPassword, doubleHash256(fakepihole.DefaultPassword))
+
+		require.NoError(t, client.Login(context.Background()))
+		require.NotEmpty(t, client.session.id)
+		require.NotEmpty(t, client.session.csrf)
+		require.Equal(t, 1, server.Sessions())
+	})
+}
+
+func TestClientTOTP(t *testing.T) {
+	const secret = "JBSWY3DPEHPK3PXP"
+
+	t.Run("Send a TOTP code on login", func(t *testing.T) {
+		t.Parallel()
+
+		key, err := decodeTOTPSecret(secret)
+		require.NoError(t, err)
+
+		mux := http.NewServeMux()
+
+		mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				Password string  `json:"password"`
+				TOTP     *uint32 `json:"totp"`
+			}
+			if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&body)) || !assert.NotNil(t, body.TOTP) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			assert.Equal(t, "test", body.Password)
+
+			// Accept the previous time step in case the test runs across a period boundary
+			now := time.Now()
+			assert.Contains(t, []uint32{totpCode(key, now, totpDigits), totpCode(key, now.Add(-totpPeriod), totpDigits)}, *body.TOTP)
+
+			w.Write([]byte(`{"session":{"valid":true,"totp":true,"sid":"sid","csrf":"csrf","validity":300}}`)) //nolint:errcheck
+		})
+
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		client := New(Config{
+			Password:   "test",
+			URL:        server.URL,
+			TOTPSecret: secret,
+		})
+
+		require.NoError(t, client.Init(context.Background()))
+		require.NoError(t, client.Login(context.Background()))
+		require.Equal(t, "sid", client.session.id)
+	})
+
+	t.Run("Wait for the next TOTP code when logging in twice within one period", func(t *testing.T) {
+		t.Parallel()
+
+		key, err := decodeTOTPSecret(secret)
+		require.NoError(t, err)
+
+		var mu sync.Mutex
+		var codes []uint32
+
+		mux := http.NewServeMux()
+
+		mux.HandleFunc("POST /api/auth", func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				TOTP *uint32 `json:"totp"`
+			}
+			if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&body)) || !assert.NotNil(t, body.TOTP) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			mu.Lock()
+			codes = append(codes, *body.TOTP)
+			mu.Unlock()
+
+			w.Write([]byte(`{"session":{"valid":true,"totp":true,"sid":"sid","csrf":"csrf","validity":300}}`)) //nolint:errcheck
+		})
+
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		client := New(Config{
+			Password:   "test",
+			URL:        server.URL,
+			TOTPSecret: secret,
+		})
+
+		// Start the clock shortly before the end of a time step so the test does not wait a whole period
+		period := time.Unix(1_700_000_010, 0)
+		start := time.Now()
+		client.session.now = func() time.Time {
+			return period.Add(-100 * time.Millisecond).Add(time.Since(start))
+		}
+
+		require.NoError(t, client.Init(context.Background()))
+		require.NoError(t, client.Login(context.Background()))
+		require.NoError(t, client.Login(context.Background()))
+
+		require.Equal(t, []uint32{
+			totpCode(key, period.Add(-totpPeriod), totpDigits),
+			totpCode(key, period, totpDigits),
+		}, codes)
+		require.Less(t, time.Since(start), totpPeriod)
+	})
+
+	t.Run("Stop waiting for the next TOTP code once the context is done", func(t *testing.T) {
+		t.Parallel()
+
 		mux := http.NewServeMux()
 
-		mux.HandleFunc("/admin/index.php", func(w http.ResponseWriter, r *http.Request) {
-			w.Header().Set("Set-Cookie", "session-id=ID;")
-			w.Write([]byte(`<div id="token">token</div>`)) //nolint:errcheck
+		mux.HandleFunc("POST /api/auth", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"session":{"valid":true,"totp":true,"sid":"sid","csrf":"csrf","validity":300}}`)) //nolint:errcheck
+		})
+
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		client := New(Config{
+			Password:   "test",
+			URL:        server.URL,
+			TOTPSecret: secret,
+		})
+
+		// Stay at the start of a time step so the second login would wait for almost a whole period
+		period := time.Unix(1_700_000_010, 0)
+		client.session.now = func() time.Time {
+			return period
+		}
+
+		require.NoError(t, client.Init(context.Background()))
+		require.NoError(t, client.Login(context.Background()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		err := client.Login(ctx)
+		require.ErrorIs(t, err, ErrLoginFailed)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("Fail login if TOTP is required but no secret is configured", func(t *testing.T) {
+		t.Parallel()
+
+		mux := http.NewServeMux()
+
+		mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error":{"key":"bad_request","message":"No 2FA token found in JSON payload","hint":null}}`)) //nolint:errcheck
 		})
 
 		server := httptest.NewServer(mux)
@@ -134,11 +374,112 @@ func TestClient(t *testing.T) {
 		})
 
 		require.NoError(t, client.Init(context.Background()))
-		require.Equal(t, client.password, "test")
-		require.Equal(t, client.webPassword, doubleHash256("test"))
+
+		err := client.Login(context.Background())
+		require.ErrorIs(t, err, ErrLoginFailed)
+		require.Contains(t, err.Error(), "no TOTP secret is configured")
+	})
+
+	t.Run("Fail validation if the TOTP secret is not base32", func(t *testing.T) {
+		t.Parallel()
+
+		client := New(Config{
+			Password:   "test",
+			URL:        "http://pi.hole",
+			TOTPSecret: "not-base32!",
+		})
+
+		err := client.Init(context.Background())
+		require.ErrorIs(t, err, ErrClientValidationFailed)
+		require.Contains(t, err.Error(), "invalid TOTP secret")
+	})
+}
+
+func TestClientAPIToken(t *testing.T) {
+	newServer := func(t *testing.T, appSudo bool) *httptest.Server {
+		mux := http.NewServeMux()
+
+		mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				Password string `json:"password"`
+			}
+			if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&body)) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			assert.Equal(t, "app-password", body.Password)
+
+			w.Write([]byte(`{"session":{"valid":true,"totp":false,"sid":"sid","csrf":"csrf","validity":300,"message":"app-password correct"}}`)) //nolint:errcheck
+		})
+
+		mux.HandleFunc("/api/config/webserver/api/app_sudo", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(fmt.Sprintf(`{"config":{"webserver":{"api":{"app_sudo":%t}}}}`, appSudo))) //nolint:errcheck
+		})
+
+		mux.HandleFunc("/api/groups", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "sid", r.Header.Get("X-FTL-SID"))
+
+			if r.Method == http.MethodPost {
+				w.WriteHeader(http.StatusCreated)
+			}
+
+			w.Write([]byte(`{"groups":[{"id":1,"name":"test","enabled":true}]}`)) //nolint:errcheck
+		})
+
+		return httptest.NewServer(mux)
+	}
+
+	t.Run("Authenticate with an application password", func(t *testing.T) {
+		t.Parallel()
+
+		server := newServer(t, false)
+		defer server.Close()
+
+		client := New(Config{
+			URL:      server.URL,
+			APIToken: "app-password",
+		})
+
+		require.NoError(t, client.Init(context.Background()))
+		require.NoError(t, client.Login(context.Background()))
+		require.Equal(t, "sid", client.session.id)
+
+		_, err := client.ListGroups(context.Background())
+		require.NoError(t, err)
+	})
+
+	t.Run("Fail writes if app_sudo is disabled", func(t *testing.T) {
+		t.Parallel()
+
+		server := newServer(t, false)
+		defer server.Close()
+
+		client := New(Config{
+			URL:      server.URL,
+			APIToken: "app-password",
+		})
+
+		require.NoError(t, client.Login(context.Background()))
+
+		_, err := client.CreateGroup(context.Background(), &GroupCreateRequest{Name: "test"})
+		require.ErrorIs(t, err, ErrAppSudoDisabled)
+		require.Contains(t, err.Error(), "POST /api/groups")
+	})
+
+	t.Run("Allow writes if app_sudo is enabled", func(t *testing.T) {
+		t.Parallel()
+
+		server := newServer(t, true)
+		defer server.Close()
+
+		client := New(Config{
+			URL:      server.URL,
+			APIToken: "app-password",
+		})
 
 		require.NoError(t, client.Login(context.Background()))
-		require.Equal(t, client.sessionID, "ID")
-		require.Equal(t, client.sessionToken, "token")
+
+		_, err := client.CreateGroup(context.Background(), &GroupCreateRequest{Name: "test"})
+		require.NoError(t, err)
 	})
 }
@@ -0,0 +1,314 @@
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type ClientResponseList struct {
+	Clients   []ClientResponse `json:"clients"`
+	Processed *struct {
+		Errors []struct {
+			Item    string `json:"item"`
+			Message string `json:"error"`
+		} `json:"errors"`
+	} `json:"processed"`
+}
+
+type ClientResponse struct {
+	ID           int64   `json:"id"`
+	Client       string  `json:"client"`
+	Name         *string `json:"name"`
+	Comment      *string `json:"comment"`
+	Groups       []int64 `json:"groups"`
+	DateAdded    int64   `json:"date_added"`
+	DateModified int64   `json:"date_modified"`
+}
+
+type GroupClient struct {
+	ID           int64
+	Client       string
+	Name         string
+	Comment      string
+	DateAdded    time.Time
+	DateModified time.Time
+	GroupIDs     []int64
+}
+
+type GroupClientList []*GroupClient
+
+type GroupClientCreateRequest struct {
+	Client   string
+	Comment  string
+	GroupIDs []int64
+}
+
+type GroupClientUpdateRequest struct {
+	Client   string
+	Comment  string
+	GroupIDs []int64
+}
+
+// ToGroupClientList converts a ClientResponseList to a GroupClientList
+func (l ClientResponseList) ToGroupClientList() GroupClientList {
+	list := make(GroupClientList, len(l.Clients))
+
+	for i, c := range l.Clients {
+		list[i] = c.ToGroupClient()
+	}
+
+	return list
+}
+
+// ToGroupClient converts a ClientResponse to a GroupClient
+func (cr ClientResponse) ToGroupClient() *GroupClient {
+	name := ""
+	if cr.Name != nil {
+		name = *cr.Name
+	}
+
+	comment := ""
+	if cr.Comment != nil {
+		comment = *cr.Comment
+	}
+
+	return &GroupClient{
+		ID:           cr.ID,
+		Client:       cr.Client,
+		Name:         name,
+		Comment:      comment,
+		DateAdded:    time.Unix(cr.DateAdded, 0),
+		DateModified: time.Unix(cr.DateModified, 0),
+		GroupIDs:     cr.Groups,
+	}
+}
+
+var validHostname = regexp.MustCompile(`^([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)(\.[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?)*$`)
+var validInterface = regexp.MustCompile(`^:[^\s:/]{1,15}$`)
+
+// NormalizeClient returns the canonical form of a client identifier: an IP address, a CIDR, a MAC address, a hostname or an interface (prefixed with ':')
+func NormalizeClient(client string) (string, error) {
+	client = strings.TrimSpace(client)
+
+	if client == "" {
+		return "", fmt.Errorf("client identifier must not be empty")
+	}
+
+	if strings.Contains(client, "/") {
+		prefix, err := netip.ParsePrefix(client)
+		if err != nil {
+			return "", fmt.Errorf("invalid CIDR %q: %s", client, err)
+		}
+
+		prefix = prefix.Masked()
+		if prefix.Addr().Is4In6() {
+			return "", fmt.Errorf("invalid CIDR %q: IPv4-mapped IPv6 prefixes are not supported", client)
+		}
+
+		return prefix.String(), nil
+	}
+
+	if addr, err := netip.ParseAddr(client); err == nil {
+		if addr.Zone() != "" {
+			return "", fmt.Errorf("invalid IP address %q: zones are not supported", client)
+		}
+
+		return addr.Unmap().String(), nil
+	}
+
+	if mac, err := net.ParseMAC(client); err == nil {
+		if len(mac) != 6 {
+			return "", fmt.Errorf("invalid MAC address %q: only 48-bit addresses are supported", client)
+		}
+
+		return strings.ToUpper(mac.String()), nil
+	}
+
+	if strings.HasPrefix(client, ":") {
+		if !validInterface.MatchString(client) {
+			return "", fmt.Errorf("invalid interface %q", client)
+		}
+
+		return client, nil
+	}
+
+	hostname := strings.ToLower(strings.TrimSuffix(client, "."))
+	if len(hostname) > 253 || !validHostname.MatchString(hostname) {
+		return "", fmt.Errorf("%q is not a valid IP address, CIDR, MAC address, hostname or interface", client)
+	}
+
+	return hostname, nil
+}
+
+// sameClient indicates whether two client identifiers refer to the same client once normalized
+func sameClient(a, b string) bool {
+	na, err := NormalizeClient(a)
+	if err != nil {
+		return a == b
+	}
+
+	nb, err := NormalizeClient(b)
+	if err != nil {
+		return a == b
+	}
+
+	return na == nb
+}
+
+// ListClients returns the list of gravity DB clients
+func (c Client) ListClients(ctx context.Context) (GroupClientList, error) {
+	req, err := c.RequestWithSession2(ctx, "GET", "/api/clients", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to retrieve clients: %w", newAPIError(res))
+	}
+
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	var response ClientResponseList
+	if err := json.Unmarshal(b, &response); err != nil {
+		return nil, err
+	}
+
+	return response.ToGroupClientList(), nil
+}
+
+// GetClient returns a Pi-hole client by identifier, matching differently formatted identifiers of the same client
+func (c Client) GetClient(ctx context.Context, client string) (*GroupClient, error) {
+	clients, err := c.ListClients(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, gc := range clients {
+		if sameClient(gc.Client, client) {
+			return gc, nil
+		}
+	}
+
+	return nil, NewNotFoundError(fmt.Sprintf("client %q not found", client))
+}
+
+// CreateClient creates a client with the passed attributes
+func (c Client) CreateClient(ctx context.Context, cr *GroupClientCreateRequest) (*GroupClient, error) {
+	client, err := NormalizeClient(cr.Client)
+	if err != nil {
+		return nil, err
+	}
+
+	data := map[string]any{
+		"client":  client,
+		"comment": cr.Comment,
+	}
+	if cr.GroupIDs != nil {
+		data["groups"] = cr.GroupIDs
+	}
+
+	req, err := c.RequestWithSession2(ctx, "POST", "/api/clients", data)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != 201 {
+		return nil, fmt.Errorf("failed to create client: %w", newAPIError(res))
+	}
+
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	var response ClientResponseList
+	if err := json.Unmarshal(b, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Processed != nil && len(response.Processed.Errors) > 0 {
+		return nil, fmt.Errorf("failed to create client %q: %s", client, response.Processed.Errors[0].Message)
+	}
+
+	if len(response.Clients) != 1 {
+		return nil, fmt.Errorf("failed to create client %q, got %d clients in response", client, len(response.Clients))
+	}
+
+	return response.Clients[0].ToGroupClient(), nil
+}
+
+// UpdateClient updates the comment and groups of a client
+func (c Client) UpdateClient(ctx context.Context, cr *GroupClientUpdateRequest) (*GroupClient, error) {
+	data := map[string]any{
+		"comment": cr.Comment,
+	}
+	if cr.GroupIDs != nil {
+		data["groups"] = cr.GroupIDs
+	}
+
+	req, err := c.RequestWithSession2(ctx, "PUT", fmt.Sprintf("/api/clients/%s", url.PathEscape(cr.Client)), data)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to update client: %w", newAPIError(res))
+	}
+
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	var response ClientResponseList
+	if err := json.Unmarshal(b, &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.Clients) != 1 {
+		return nil, fmt.Errorf("failed to update client %q, got %d clients in response", cr.Client, len(response.Clients))
+	}
+
+	return response.Clients[0].ToGroupClient(), nil
+}
+
+// DeleteClient deletes a client
+func (c Client) DeleteClient(ctx context.Context, client string) error {
+	req, err := c.RequestWithSession2(ctx, "DELETE", fmt.Sprintf("/api/clients/%s", url.PathEscape(client)), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != 204 {
+		return fmt.Errorf("failed to delete client: %w", newAPIError(res))
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package pihole
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizeClient(t *testing.T) {
+	t.Run("Normalize valid client identifiers", func(t *testing.T) {
+		t.Parallel()
+
+		cases := map[string]string{
+			"192.168.1.10":      "192.168.1.10",
+			" 192.168.1.10 ":    "192.168.1.10",
+			"::ffff:10.0.0.1":   "10.0.0.1",
+			"2001:DB8:0:0::1":   "2001:db8::1",
+			"192.168.1.5/24":    "192.168.1.0/24",
+			"2001:db8::1/64":    "2001:db8::/64",
+			"aa:bb:cc:dd:ee:ff": "AA:BB:CC:DD:EE:FF",
+			"AA-BB-CC-DD-EE-FF": "AA:BB:CC:DD:EE:FF",
+			"aabb.ccdd.eeff":    "AA:BB:CC:DD:EE:FF",
+			"Laptop.lan.":       "laptop.lan",
+			"my-host":           "my-host",
+			":eth0":             ":eth0",
+		}
+
+		for in, expected := range cases {
+			actual, err := NormalizeClient(in)
+			require.NoError(t, err, in)
+			require.Equal(t, expected, actual, in)
+		}
+	})
+
+	t.Run("Reject invalid client identifiers", func(t *testing.T) {
+		t.Parallel()
+
+		for _, in := range []string{
+			"",
+			"192.168.1.0/33",
+			"fe80::1%eth0",
+			"00:00:00:00:fe:80:00:00:00:00:00:00:02:00:5e:10:00:00:00:01",
+			"-invalid-",
+			"under_score",
+			":",
+			":eth/0",
+		} {
+			_, err := NormalizeClient(in)
+			require.Error(t, err, in)
+		}
+	})
+
+	t.Run("Match differently formatted identifiers", func(t *testing.T) {
+		t.Parallel()
+
+		require.True(t, sameClient("aa-bb-cc-dd-ee-ff", "AA:BB:CC:DD:EE:FF"))
+		require.True(t, sameClient("10.0.0.7/8", "10.0.0.0/8"))
+		require.False(t, sameClient("10.0.0.1", "10.0.0.2"))
+	})
+}
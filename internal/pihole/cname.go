@@ -3,12 +3,14 @@ package pihole
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
+	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 
-	pihole "github.com/ryanwholey/go-pihole"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 type CNAMERecordsListResponse struct {
@@ -29,15 +31,109 @@ func (rr CNAMERecordsListResponse) ToCNAMERecordList() CNAMERecordList {
 	return list
 }
 
-type CNAMERecord = pihole.CNAMERecord
-type CNAMERecordList = pihole.CNAMERecordList
+type CNAMERecord struct {
+	Domain string
+	Target string
+
+	// TTL is the time to live of the record in seconds, 0 when the record uses the default TTL
+	TTL int
+
+	// entry is the raw dns.cnameRecords entry the record was parsed from
+	entry string
+	// shared indicates whether the entry holds other domains as well
+	shared bool
+}
+
+type CNAMERecordList []CNAMERecord
+
+// Entry returns the dns.cnameRecords entry representing the record
+func (r CNAMERecord) Entry() string {
+	if r.entry != "" {
+		return r.entry
+	}
+
+	fields := []string{r.Domain, r.Target}
+	if r.TTL > 0 {
+		fields = append(fields, strconv.Itoa(r.TTL))
+	}
+
+	return strings.Join(fields, ",")
+}
+
+// parseCNAMERecords parses a dns.cnameRecords entry of the form domain[,domain...],target[,TTL] into one record
+// per domain
+func parseCNAMERecords(entry string) (CNAMERecordList, error) {
+	fields := strings.Split(entry, ",")
+	for i, field := range fields {
+		fields[i] = strings.TrimSpace(field)
+	}
+
+	ttl := 0
+	if len(fields) > 2 {
+		// A numeric last field is the TTL, targets are never numeric
+		last := fields[len(fields)-1]
+		if _, err := strconv.Atoi(last); err == nil {
+			v, err := strconv.ParseUint(last, 10, 31)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse TTL of CNAME record %q: %w", entry, err)
+			}
+
+			ttl = int(v)
+			fields = fields[:len(fields)-1]
+		}
+	}
+
+	if len(fields) < 2 || slices.Contains(fields, "") {
+		return nil, fmt.Errorf("failed to parse CNAME record %q", entry)
+	}
+
+	target := fields[len(fields)-1]
+	domains := fields[:len(fields)-1]
+
+	list := make(CNAMERecordList, 0, len(domains))
+	for _, domain := range domains {
+		list = append(list, CNAMERecord{
+			Domain: domain,
+			Target: target,
+			TTL:    ttl,
+			entry:  entry,
+			shared: len(domains) > 1,
+		})
+	}
+
+	return list, nil
+}
+
+// sharedCNAMEEntryError returns the error of a change to a record whose dns.cnameRecords entry holds other domains,
+// which cannot be changed without changing them as well
+func sharedCNAMEEntryError(record CNAMERecord) error {
+	return fmt.Errorf("CNAME record %q shares the dns.cnameRecords entry %q with other domains, split the entry in Pi-hole to manage the record", record.Domain, record.entry)
+}
 
 // ListCNAMERecords returns a list of the configured CNAME Pi-hole records
 func (c Client) ListCNAMERecords(ctx context.Context) (CNAMERecordList, error) {
-	if c.tokenClient != nil {
-		return nil, fmt.Errorf("%w: list dns records", ErrNotImplementedTokenClient)
+	entries, err := c.listCNAMERecordEntries(ctx)
+	if err != nil {
+		return nil, err
 	}
 
+	var list CNAMERecordList
+	for _, v := range entries {
+		// A hand-edited entry that is not a valid CNAME record must not break reading the other records
+		records, err := parseCNAMERecords(v)
+		if err != nil {
+			tflog.Warn(ctx, "Skipping malformed dns.cnameRecords entry", map[string]interface{}{"entry": v, "error": err.Error()})
+			continue
+		}
+
+		list = append(list, records...)
+	}
+
+	return list, nil
+}
+
+// listCNAMERecordEntries returns the raw dns.cnameRecords entries
+func (c Client) listCNAMERecordEntries(ctx context.Context) ([]string, error) {
 	req, err := c.RequestWithSession2(ctx, "GET", "/api/config/dns/cnameRecords", nil)
 	if err != nil {
 		return nil, err
@@ -48,7 +144,7 @@ func (c Client) ListCNAMERecords(ctx context.Context) (CNAMERecordList, error) {
 		return nil, err
 	}
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to retrieve dns records, got status code %d", res.StatusCode)
+		return nil, fmt.Errorf("failed to retrieve dns records: %w", newAPIError(res))
 	}
 
 	defer res.Body.Close()
@@ -68,36 +164,11 @@ func (c Client) ListCNAMERecords(ctx context.Context) (CNAMERecordList, error) {
 		return nil, err
 	}
 
-	var list pihole.CNAMERecordList
-	for _, v := range response.Config.DNS.Hosts {
-		splitted := strings.Split(v, ",")
-		if len(splitted) != 2 {
-			return nil, fmt.Errorf("failed to parse dns records")
-		}
-		list = append(list, pihole.CNAMERecord{
-			Domain: splitted[0],
-			Target: splitted[1],
-		})
-	}
-
-	return list, nil
+	return response.Config.DNS.Hosts, nil
 }
 
 // GetCNAMERecord returns a CNAMERecord for the passed domain if found
 func (c Client) GetCNAMERecord(ctx context.Context, domain string) (*CNAMERecord, error) {
-	if c.tokenClient != nil {
-		record, err := c.tokenClient.LocalCNAME.Get(ctx, domain)
-		if err != nil {
-			if errors.Is(err, pihole.ErrorLocalCNAMENotFound) {
-				return nil, NewNotFoundError(fmt.Sprintf("cname with domain %q not found", domain))
-			}
-
-			return nil, err
-		}
-
-		return record, nil
-	}
-
 	list, err := c.ListCNAMERecords(ctx)
 	if err != nil {
 		return nil, err
@@ -117,42 +188,52 @@ type CreateCNAMERecordResponse struct {
 	Message string
 }
 
-// CreateCNAMERecord handles CNAME record creation
+// CreateCNAMERecord handles CNAME record creation, the entry carries the TTL of the record if set
 func (c Client) CreateCNAMERecord(ctx context.Context, record *CNAMERecord) (*CNAMERecord, error) {
-	if c.tokenClient != nil {
-		return c.tokenClient.LocalCNAME.Create(ctx, record.Domain, record.Target)
+	c.dnsMu.Lock()
+	defer c.dnsMu.Unlock()
+
+	if err := c.addCNAMERecordEntry(ctx, record.Entry()); err != nil {
+		return nil, err
 	}
 
-	cfg := strings.Join([]string{record.Domain, record.Target}, "%2C")
-	req, err := c.RequestWithSession2(ctx, "PUT", fmt.Sprintf("/api/config/dns/cnameRecords/%s", cfg), nil)
+	return record, nil
+}
+
+// addCNAMERecordEntry adds an entry to dns.cnameRecords
+func (c Client) addCNAMERecordEntry(ctx context.Context, entry string) error {
+	req, err := c.RequestWithSession2(ctx, "PUT", fmt.Sprintf("/api/config/dns/cnameRecords/%s", url.PathEscape(entry)), nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	res, err := c.client.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if res.StatusCode != 201 {
-		return nil, fmt.Errorf("failed to create CNAME records, got status code %d", res.StatusCode)
+		return fmt.Errorf("failed to create CNAME records: %w", newAPIError(res))
 	}
 
-	return record, nil
+	return nil
 }
 
-// DeleteCNAMERecord handles CNAME record deletion for the passed domain
+// DeleteCNAMERecord handles CNAME record deletion for the passed domain. Records sharing their entry with other
+// domains are not deleted.
 func (c Client) DeleteCNAMERecord(ctx context.Context, domain string) error {
-	if c.tokenClient != nil {
-		return c.tokenClient.LocalCNAME.Delete(ctx, domain)
-	}
+	c.dnsMu.Lock()
+	defer c.dnsMu.Unlock()
 
 	record, err := c.GetCNAMERecord(ctx, domain)
 	if err != nil {
 		return err
 	}
 
-	cfg := strings.Join([]string{record.Domain, record.Target}, "%2C")
-	req, err := c.RequestWithSession2(ctx, "DELETE", fmt.Sprintf("/api/config/dns/cnameRecords/%s", cfg), nil)
+	if record.shared {
+		return sharedCNAMEEntryError(*record)
+	}
+
+	req, err := c.RequestWithSession2(ctx, "DELETE", fmt.Sprintf("/api/config/dns/cnameRecords/%s", url.PathEscape(record.Entry())), nil)
 	if err != nil {
 		return err
 	}
@@ -162,8 +243,58 @@ func (c Client) DeleteCNAMERecord(ctx context.Context, domain string) error {
 		return err
 	}
 	if res.StatusCode != 204 {
-		return fmt.Errorf("failed to delete CNAME records, got status code %d", res.StatusCode)
+		return fmt.Errorf("failed to delete CNAME records: %w", newAPIError(res))
 	}
 
 	return nil
 }
+
+// UpdateCNAMERecord changes the target and TTL of the CNAME record of the passed domain. The whole dns.cnameRecords
+// array is replaced in a single request, so that the domain keeps resolving during the change.
+func (c Client) UpdateCNAMERecord(ctx context.Context, record *CNAMERecord) (*CNAMERecord, error) {
+	c.dnsMu.Lock()
+	defer c.dnsMu.Unlock()
+
+	entries, err := c.listCNAMERecordEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := replaceCNAMERecordEntry(entries, CNAMERecord{Domain: record.Domain, Target: record.Target, TTL: record.TTL})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.setDNSConfigArray(ctx, "cnameRecords", updated); err != nil {
+		return nil, fmt.Errorf("failed to update CNAME record %q: %w", record.Domain, err)
+	}
+
+	return record, nil
+}
+
+// replaceCNAMERecordEntry returns a copy of the dns.cnameRecords entries in which the entry of the domain of the
+// record is replaced by the record. Entries shared with other domains are not replaced.
+func replaceCNAMERecordEntry(entries []string, record CNAMERecord) ([]string, error) {
+	for i, entry := range entries {
+		records, err := parseCNAMERecords(entry)
+		if err != nil {
+			continue
+		}
+
+		j := slices.IndexFunc(records, func(r CNAMERecord) bool { return r.Domain == record.Domain })
+		if j < 0 {
+			continue
+		}
+
+		if records[j].shared {
+			return nil, sharedCNAMEEntryError(records[j])
+		}
+
+		updated := slices.Clone(entries)
+		updated[i] = record.Entry()
+
+		return updated, nil
+	}
+
+	return nil, NewNotFoundError(fmt.Sprintf("cname with domain %q not found", record.Domain))
+}
@@ -0,0 +1,95 @@
+package pihole
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCNAMERecords(t *testing.T) {
+	t.Run("Parse dns.cnameRecords entries", func(t *testing.T) {
+		t.Parallel()
+
+		cases := map[string][]CNAMERecord{
+			"foo.lan,bar.lan": {
+				{Domain: "foo.lan", Target: "bar.lan"},
+			},
+			"foo.lan,bar.lan,300": {
+				{Domain: "foo.lan", Target: "bar.lan", TTL: 300},
+			},
+			"foo.lan, bar.lan, 3600 ": {
+				{Domain: "foo.lan", Target: "bar.lan", TTL: 3600},
+			},
+			"a.lan,b.lan,target.lan": {
+				{Domain: "a.lan", Target: "target.lan"},
+				{Domain: "b.lan", Target: "target.lan"},
+			},
+			"a.lan, b.lan,target.lan,60": {
+				{Domain: "a.lan", Target: "target.lan", TTL: 60},
+				{Domain: "b.lan", Target: "target.lan", TTL: 60},
+			},
+		}
+
+		for entry, expected := range cases {
+			records, err := parseCNAMERecords(entry)
+			require.NoError(t, err, entry)
+			require.Len(t, records, len(expected), entry)
+
+			for i, record := range records {
+				require.Equal(t, expected[i].Domain, record.Domain, entry)
+				require.Equal(t, expected[i].Target, record.Target, entry)
+				require.Equal(t, expected[i].TTL, record.TTL, entry)
+				require.Equal(t, len(expected) > 1, record.shared, entry)
+				require.Equal(t, entry, record.Entry(), entry)
+			}
+		}
+	})
+
+	t.Run("Fail to parse malformed entries", func(t *testing.T) {
+		t.Parallel()
+
+		for _, entry := range []string{"", "foo.lan", "foo.lan,", ",bar.lan", "foo.lan,bar.lan,", "foo.lan,bar.lan,-1", "a.lan,,target.lan"} {
+			_, err := parseCNAMERecords(entry)
+			require.Error(t, err, entry)
+		}
+	})
+
+	t.Run("Format new entries", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, "foo.lan,bar.lan", CNAMERecord{Domain: "foo.lan", Target: "bar.lan"}.Entry())
+		require.Equal(t, "foo.lan,bar.lan,300", CNAMERecord{Domain: "foo.lan", Target: "bar.lan", TTL: 300}.Entry())
+	})
+}
+
+func TestReplaceCNAMERecordEntry(t *testing.T) {
+	t.Run("Replace an entry in place", func(t *testing.T) {
+		t.Parallel()
+
+		updated, err := replaceCNAMERecordEntry([]string{"foo.lan,bar.lan", "a.lan,b.lan"}, CNAMERecord{Domain: "foo.lan", Target: "baz.lan", TTL: 60})
+		require.NoError(t, err)
+		require.Equal(t, []string{"foo.lan,baz.lan,60", "a.lan,b.lan"}, updated)
+	})
+
+	t.Run("Leave malformed entries untouched", func(t *testing.T) {
+		t.Parallel()
+
+		updated, err := replaceCNAMERecordEntry([]string{"foo.lan,,target.lan", "foo.lan,bar.lan"}, CNAMERecord{Domain: "foo.lan", Target: "baz.lan"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"foo.lan,,target.lan", "foo.lan,baz.lan"}, updated)
+	})
+
+	t.Run("Fail if the domain shares its entry with other domains", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := replaceCNAMERecordEntry([]string{"a.lan,b.lan,target.lan"}, CNAMERecord{Domain: "b.lan", Target: "baz.lan"})
+		require.ErrorContains(t, err, "shares the dns.cnameRecords entry")
+	})
+
+	t.Run("Fail if the domain is not found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := replaceCNAMERecordEntry([]string{"foo.lan,bar.lan"}, CNAMERecord{Domain: "bar.lan", Target: "baz.lan"})
+		require.ErrorAs(t, err, new(*NotFoundError))
+	})
+}
@@ -0,0 +1,190 @@
+package pihole
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+)
+
+var validConfigPathSegment = regexp.MustCompile(`^[A-Za-z0-9_]+$`)
+
+// splitConfigPath splits a dotted config path such as dns.blocking.mode into its segments
+func splitConfigPath(path string) ([]string, error) {
+	segments := strings.Split(path, ".")
+
+	for _, s := range segments {
+		if !validConfigPathSegment.MatchString(s) {
+			return nil, fmt.Errorf("invalid config path %q", path)
+		}
+	}
+
+	return segments, nil
+}
+
+// ValidateConfigPath checks that a dotted config path is well formed
+func ValidateConfigPath(path string) error {
+	_, err := splitConfigPath(path)
+
+	return err
+}
+
+// GetConfig returns the JSON encoded config tree, limited to the passed dotted section (e.g. dns or dns.blocking) if not empty.
+// The returned tree always starts at the root, so values keep the same path regardless of the requested section.
+func (c Client) GetConfig(ctx context.Context, section string) (json.RawMessage, error) {
+	path := "/api/config"
+	if section != "" {
+		segments, err := splitConfigPath(section)
+		if err != nil {
+			return nil, err
+		}
+
+		path = fmt.Sprintf("%s/%s", path, strings.Join(segments, "/"))
+	}
+
+	req, err := c.RequestWithSession2(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to retrieve config: %w", newAPIError(res))
+	}
+
+	defer res.Body.Close()
+	type Response struct {
+		Config json.RawMessage `json:"config"`
+	}
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	var response Response
+	if err := json.Unmarshal(b, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Config == nil {
+		return nil, fmt.Errorf("failed to retrieve config, missing config in response")
+	}
+
+	return response.Config, nil
+}
+
+// GetConfigValue returns the JSON encoded value of the config setting at the passed dotted path, e.g. dns.blocking.mode
+func (c Client) GetConfigValue(ctx context.Context, path string) (json.RawMessage, error) {
+	segments, err := splitConfigPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := c.GetConfig(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, s := range segments {
+		var section map[string]json.RawMessage
+		if err := json.Unmarshal(value, &section); err != nil {
+			return nil, fmt.Errorf("failed to parse config value %q: %s", path, err)
+		}
+
+		v, ok := section[s]
+		if !ok {
+			return nil, NewNotFoundError(fmt.Sprintf("config value %q not found", path))
+		}
+
+		value = v
+	}
+
+	return value, nil
+}
+
+// FlattenConfig flattens a JSON encoded config tree to a map keyed by dotted path. String values are kept as is,
+// all other values (numbers, booleans, arrays and null) are JSON encoded.
+func FlattenConfig(config json.RawMessage) (map[string]string, error) {
+	decoder := json.NewDecoder(bytes.NewReader(config))
+	decoder.UseNumber()
+
+	var tree map[string]any
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, fmt.Errorf("failed to parse config: %s", err)
+	}
+
+	values := map[string]string{}
+	if err := flattenConfigSection(values, "", tree); err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+// flattenConfigSection adds the values of a config section to the flattened map, prefixing keys with the section path
+func flattenConfigSection(values map[string]string, prefix string, section map[string]any) error {
+	for k, v := range section {
+		path := k
+		if prefix != "" {
+			path = prefix + "." + k
+		}
+
+		switch value := v.(type) {
+		case map[string]any:
+			if err := flattenConfigSection(values, path, value); err != nil {
+				return err
+			}
+		case string:
+			values[path] = value
+		default:
+			b, err := json.Marshal(value)
+			if err != nil {
+				return err
+			}
+
+			values[path] = string(b)
+		}
+	}
+
+	return nil
+}
+
+// SetConfigValue sets the config setting at the passed dotted path to the JSON encoded value
+func (c Client) SetConfigValue(ctx context.Context, path string, value json.RawMessage) error {
+	segments, err := splitConfigPath(path)
+	if err != nil {
+		return err
+	}
+
+	if !json.Valid(value) {
+		return fmt.Errorf("config value for %q is not valid JSON", path)
+	}
+
+	var tree any = value
+	for i := len(segments) - 1; i >= 0; i-- {
+		tree = map[string]any{segments[i]: tree}
+	}
+
+	req, err := c.RequestWithSession2(ctx, "PATCH", "/api/config", map[string]any{
+		"config": tree,
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != 200 {
+		return fmt.Errorf("failed to set config value %q: %w", path, newAPIError(res))
+	}
+	defer res.Body.Close()
+
+	return nil
+}
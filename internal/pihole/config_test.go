@@ -0,0 +1,63 @@
+package pihole
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateConfigPath(t *testing.T) {
+	t.Run("Accept dotted config paths", func(t *testing.T) {
+		t.Parallel()
+
+		for _, path := range []string{"dns", "dns.blocking.mode", "webserver.api.app_sudo", "dns.queryLogging"} {
+			require.NoError(t, ValidateConfigPath(path), path)
+		}
+	})
+
+	t.Run("Reject malformed config paths", func(t *testing.T) {
+		t.Parallel()
+
+		for _, path := range []string{"", ".", "dns.", ".dns", "dns..blocking", "dns/blocking", "dns.blocking mode", "../auth"} {
+			require.Error(t, ValidateConfigPath(path), path)
+		}
+	})
+}
+
+func TestFlattenConfig(t *testing.T) {
+	t.Run("Flatten nested sections to dotted paths", func(t *testing.T) {
+		t.Parallel()
+
+		values, err := FlattenConfig([]byte(`{
+			"dns": {
+				"domain": "lan",
+				"upstreams": ["9.9.9.9", "149.112.112.112"],
+				"blocking": {"active": true, "mode": "NULL"},
+				"cache": {"size": 10000, "optimizer": 3600},
+				"hosts": []
+			},
+			"dhcp": {"router": "", "leaseTime": null, "ratio": 0.5}
+		}`))
+		require.NoError(t, err)
+
+		require.Equal(t, map[string]string{
+			"dns.domain":          "lan",
+			"dns.upstreams":       `["9.9.9.9","149.112.112.112"]`,
+			"dns.blocking.active": "true",
+			"dns.blocking.mode":   "NULL",
+			"dns.cache.size":      "10000",
+			"dns.cache.optimizer": "3600",
+			"dns.hosts":           "[]",
+			"dhcp.router":         "",
+			"dhcp.leaseTime":      "null",
+			"dhcp.ratio":          "0.5",
+		}, values)
+	})
+
+	t.Run("Reject non-object config", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := FlattenConfig([]byte(`["dns"]`))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,299 @@
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/netip"
+	"net/url"
+	"strings"
+)
+
+type DHCPStaticLease struct {
+	MAC      string
+	IP       string
+	Hostname string
+
+	// entry is the raw dhcp.hosts entry the lease was parsed from
+	entry string
+}
+
+type DHCPStaticLeaseList []DHCPStaticLease
+
+// Entry returns the dhcp.hosts entry representing the lease
+func (l DHCPStaticLease) Entry() string {
+	if l.entry != "" {
+		return l.entry
+	}
+
+	fields := []string{l.MAC, l.IP}
+	if l.Hostname != "" {
+		fields = append(fields, l.Hostname)
+	}
+
+	return strings.Join(fields, ",")
+}
+
+// NormalizeMAC returns the canonical upper case, colon separated form of a MAC address
+func NormalizeMAC(mac string) (string, error) {
+	hw, err := net.ParseMAC(strings.TrimSpace(mac))
+	if err != nil {
+		return "", err
+	}
+
+	if len(hw) != 6 {
+		return "", fmt.Errorf("invalid MAC address %q: only 48-bit addresses are supported", mac)
+	}
+
+	return strings.ToUpper(hw.String()), nil
+}
+
+// parseDHCPStaticLease parses a dhcp.hosts entry of the form MAC,IP[,hostname]. Additional dnsmasq
+// dhcp-host fields such as lease times or tags are ignored.
+func parseDHCPStaticLease(entry string) (*DHCPStaticLease, error) {
+	lease := &DHCPStaticLease{entry: entry}
+
+	for _, field := range strings.Split(entry, ",") {
+		field = strings.TrimSpace(field)
+
+		if mac, err := NormalizeMAC(field); err == nil && lease.MAC == "" {
+			lease.MAC = mac
+			continue
+		}
+
+		if addr, err := netip.ParseAddr(strings.Trim(field, "[]")); err == nil && lease.IP == "" {
+			lease.IP = addr.String()
+			continue
+		}
+
+		if lease.Hostname == "" && field != "infinite" && field != "ignore" && !isLeaseTime(field) && validHostname.MatchString(strings.ToLower(field)) {
+			lease.Hostname = field
+		}
+	}
+
+	if lease.MAC == "" || lease.IP == "" {
+		return nil, fmt.Errorf("failed to parse dhcp static lease %q", entry)
+	}
+
+	return lease, nil
+}
+
+// isLeaseTime indicates whether a dhcp-host field is a lease time such as 45m or 12h
+func isLeaseTime(field string) bool {
+	if field == "" {
+		return false
+	}
+
+	number := strings.TrimRight(field, "smhdw")
+	if number == "" || len(field)-len(number) > 1 {
+		return false
+	}
+
+	for _, r := range number {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ListDHCPStaticLeases returns the list of static DHCP leases configured in pihole
+func (c Client) ListDHCPStaticLeases(ctx context.Context) (DHCPStaticLeaseList, error) {
+	req, err := c.RequestWithSession2(ctx, "GET", "/api/config/dhcp/hosts", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to retrieve dhcp static leases: %w", newAPIError(res))
+	}
+
+	defer res.Body.Close()
+	type Response struct {
+		Config struct {
+			DHCP struct {
+				Hosts []string `json:"hosts"`
+			} `json:"dhcp"`
+		} `json:"config"`
+	}
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	var response Response
+	if err := json.Unmarshal(b, &response); err != nil {
+		return nil, err
+	}
+
+	var list DHCPStaticLeaseList
+	for _, v := range response.Config.DHCP.Hosts {
+		// dhcp.hosts accepts any dnsmasq dhcp-host entry, only MAC to IP reservations are managed as static leases
+		lease, err := parseDHCPStaticLease(v)
+		if err != nil {
+			continue
+		}
+
+		list = append(list, *lease)
+	}
+
+	return list, nil
+}
+
+// GetDHCPStaticLease returns the static DHCP lease for the passed MAC address if found
+func (c Client) GetDHCPStaticLease(ctx context.Context, mac string) (*DHCPStaticLease, error) {
+	normalized, err := NormalizeMAC(mac)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := c.ListDHCPStaticLeases(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, l := range list {
+		if l.MAC == normalized {
+			return &l, nil
+		}
+	}
+
+	return nil, NewNotFoundError(fmt.Sprintf("dhcp static lease with MAC %q not found", mac))
+}
+
+// CreateDHCPStaticLease creates a static DHCP lease
+func (c Client) CreateDHCPStaticLease(ctx context.Context, lease *DHCPStaticLease) (*DHCPStaticLease, error) {
+	mac, err := NormalizeMAC(lease.MAC)
+	if err != nil {
+		return nil, err
+	}
+
+	created := &DHCPStaticLease{
+		MAC:      mac,
+		IP:       lease.IP,
+		Hostname: lease.Hostname,
+	}
+
+	req, err := c.RequestWithSession2(ctx, "PUT", fmt.Sprintf("/api/config/dhcp/hosts/%s", url.PathEscape(created.Entry())), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != 201 {
+		return nil, fmt.Errorf("failed to create dhcp static lease: %w", newAPIError(res))
+	}
+
+	return created, nil
+}
+
+// DeleteDHCPStaticLease deletes the static DHCP lease of the passed MAC address
+func (c Client) DeleteDHCPStaticLease(ctx context.Context, mac string) error {
+	lease, err := c.GetDHCPStaticLease(ctx, mac)
+	if err != nil {
+		return err
+	}
+
+	req, err := c.RequestWithSession2(ctx, "DELETE", fmt.Sprintf("/api/config/dhcp/hosts/%s", url.PathEscape(lease.Entry())), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != 204 {
+		return fmt.Errorf("failed to delete dhcp static lease: %w", newAPIError(res))
+	}
+
+	return nil
+}
+
+type DHCPSettings struct {
+	Active      bool   `json:"active"`
+	Start       string `json:"start"`
+	End         string `json:"end"`
+	Router      string `json:"router"`
+	Netmask     string `json:"netmask"`
+	LeaseTime   string `json:"leaseTime"`
+	IPv6        bool   `json:"ipv6"`
+	RapidCommit bool   `json:"rapidCommit"`
+}
+
+// DefaultDHCPSettings are the Pi-hole defaults of the dhcp config section, leaving the DHCP server disabled
+var DefaultDHCPSettings = DHCPSettings{
+	Active:      false,
+	Start:       "",
+	End:         "",
+	Router:      "",
+	Netmask:     "",
+	LeaseTime:   "",
+	IPv6:        false,
+	RapidCommit: false,
+}
+
+// GetDHCPSettings returns the DHCP server configuration
+func (c Client) GetDHCPSettings(ctx context.Context) (*DHCPSettings, error) {
+	req, err := c.RequestWithSession2(ctx, "GET", "/api/config/dhcp", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to retrieve dhcp settings: %w", newAPIError(res))
+	}
+
+	defer res.Body.Close()
+	type Response struct {
+		Config struct {
+			DHCP DHCPSettings `json:"dhcp"`
+		} `json:"config"`
+	}
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	var response Response
+	if err := json.Unmarshal(b, &response); err != nil {
+		return nil, err
+	}
+
+	return &response.Config.DHCP, nil
+}
+
+// UpdateDHCPSettings patches the DHCP server configuration, leaving dhcp.hosts untouched
+func (c Client) UpdateDHCPSettings(ctx context.Context, settings *DHCPSettings) (*DHCPSettings, error) {
+	req, err := c.RequestWithSession2(ctx, "PATCH", "/api/config", map[string]any{
+		"config": map[string]any{
+			"dhcp": settings,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to update dhcp settings: %w", newAPIError(res))
+	}
+	defer res.Body.Close()
+
+	return c.GetDHCPSettings(ctx)
+}
@@ -0,0 +1,60 @@
+package pihole
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDHCPStaticLease(t *testing.T) {
+	t.Run("Parse dhcp.hosts entries", func(t *testing.T) {
+		t.Parallel()
+
+		cases := map[string]DHCPStaticLease{
+			"aa:bb:cc:dd:ee:ff,192.168.1.10,laptop": {
+				MAC:      "AA:BB:CC:DD:EE:FF",
+				IP:       "192.168.1.10",
+				Hostname: "laptop",
+			},
+			"AA-BB-CC-DD-EE-FF, 192.168.1.11": {
+				MAC: "AA:BB:CC:DD:EE:FF",
+				IP:  "192.168.1.11",
+			},
+			"aa:bb:cc:dd:ee:ff,set:iot,192.168.1.12,Printer,12h": {
+				MAC:      "AA:BB:CC:DD:EE:FF",
+				IP:       "192.168.1.12",
+				Hostname: "Printer",
+			},
+			"aa:bb:cc:dd:ee:ff,[2001:db8::10],nas,infinite": {
+				MAC:      "AA:BB:CC:DD:EE:FF",
+				IP:       "2001:db8::10",
+				Hostname: "nas",
+			},
+		}
+
+		for entry, expected := range cases {
+			lease, err := parseDHCPStaticLease(entry)
+			require.NoError(t, err, entry)
+			require.Equal(t, expected.MAC, lease.MAC, entry)
+			require.Equal(t, expected.IP, lease.IP, entry)
+			require.Equal(t, expected.Hostname, lease.Hostname, entry)
+			require.Equal(t, entry, lease.Entry(), entry)
+		}
+	})
+
+	t.Run("Fail to parse entries without MAC or IP", func(t *testing.T) {
+		t.Parallel()
+
+		for _, entry := range []string{"", "192.168.1.10,laptop", "aa:bb:cc:dd:ee:ff,laptop"} {
+			_, err := parseDHCPStaticLease(entry)
+			require.Error(t, err, entry)
+		}
+	})
+
+	t.Run("Format new entries", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, "AA:BB:CC:DD:EE:FF,192.168.1.10,laptop", DHCPStaticLease{MAC: "AA:BB:CC:DD:EE:FF", IP: "192.168.1.10", Hostname: "laptop"}.Entry())
+		require.Equal(t, "AA:BB:CC:DD:EE:FF,192.168.1.10", DHCPStaticLease{MAC: "AA:BB:CC:DD:EE:FF", IP: "192.168.1.10"}.Entry())
+	})
+}
@@ -3,12 +3,15 @@ package pihole
 import (
 	"context"
 	"encoding/json"
-	"errors"
 	"fmt"
 	"io"
+	"net/netip"
+	"net/url"
+	"slices"
 	"strings"
+	"unicode"
 
-	"github.com/ryanwholey/go-pihole"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 type DNSRecordsListResponse struct {
@@ -29,15 +32,145 @@ func (rr DNSRecordsListResponse) ToDNSRecordList() DNSRecordList {
 	return list
 }
 
-type DNSRecordList = pihole.DNSRecordList
-type DNSRecord = pihole.DNSRecord
+type DNSRecord struct {
+	IP     string
+	Domain string
+
+	// entry is the raw dns.hosts entry the record was parsed from
+	entry string
+}
+
+type DNSRecordList []DNSRecord
+
+// Entry returns the dns.hosts entry representing the record
+func (r DNSRecord) Entry() string {
+	if r.entry != "" {
+		return r.entry
+	}
+
+	return r.IP + " " + r.Domain
+}
+
+// parseDNSHosts parses a dns.hosts entry in hosts file syntax, an IP followed by one or more hostnames separated
+// by any whitespace and an optional # comment, into one record per hostname
+func parseDNSHosts(entry string) (DNSRecordList, error) {
+	line, _, _ := strings.Cut(entry, "#")
+
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return nil, fmt.Errorf("failed to parse dns record %q", entry)
+	}
+
+	if _, err := netip.ParseAddr(fields[0]); err != nil {
+		return nil, fmt.Errorf("failed to parse dns record %q: %w", entry, err)
+	}
+
+	list := make(DNSRecordList, 0, len(fields)-1)
+	for _, hostname := range fields[1:] {
+		list = append(list, DNSRecord{
+			IP:     fields[0],
+			Domain: hostname,
+			entry:  entry,
+		})
+	}
+
+	return list, nil
+}
+
+// hostsFieldSpans returns the start and end offsets of the whitespace separated fields of a dns.hosts entry,
+// ignoring its comment
+func hostsFieldSpans(entry string) [][2]int {
+	line, _, _ := strings.Cut(entry, "#")
+
+	var spans [][2]int
+	start := -1
+	for i, r := range line {
+		if !unicode.IsSpace(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+
+		if start >= 0 {
+			spans = append(spans, [2]int{start, i})
+			start = -1
+		}
+	}
+
+	if start >= 0 {
+		spans = append(spans, [2]int{start, len(line)})
+	}
+
+	return spans
+}
+
+// withoutHostname returns the dns.hosts entry of the record without its hostname, or an empty string if the
+// entry has no other hostname. The other hostnames, the spacing and the comment of the entry are kept.
+func (r DNSRecord) withoutHostname() string {
+	entry := r.Entry()
+
+	spans := hostsFieldSpans(entry)
+	if len(spans) < 2 {
+		return ""
+	}
+
+	remaining := entry
+	kept := 0
+	// Remove the hostname along with the whitespace preceding it, from the end so that the offsets stay valid
+	for k := len(spans) - 1; k >= 1; k-- {
+		if entry[spans[k][0]:spans[k][1]] != r.Domain {
+			kept++
+			continue
+		}
+
+		remaining = remaining[:spans[k-1][1]] + remaining[spans[k][1]:]
+	}
+
+	if kept == 0 {
+		return ""
+	}
+
+	return remaining
+}
+
+// withIP returns the dns.hosts entry of the record resolving to the passed IP. The spacing and the comment of the
+// entry are kept.
+func (r DNSRecord) withIP(ip string) string {
+	entry := r.Entry()
+
+	spans := hostsFieldSpans(entry)
+	if len(spans) == 0 {
+		return DNSRecord{IP: ip, Domain: r.Domain}.Entry()
+	}
+
+	return entry[:spans[0][0]] + ip + entry[spans[0][1]:]
+}
 
 // ListDNSRecords Returns the list of custom DNS records configured in pihole
 func (c Client) ListDNSRecords(ctx context.Context) (DNSRecordList, error) {
-	if c.tokenClient != nil {
-		return nil, fmt.Errorf("%w: list dns records", ErrNotImplementedTokenClient)
+	entries, err := c.listDNSHostsEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var list DNSRecordList
+	for _, v := range entries {
+		// A hand-edited entry that is not a valid hosts line must not break reading the other records
+		records, err := parseDNSHosts(v)
+		if err != nil {
+			tflog.Warn(ctx, "Skipping malformed dns.hosts entry", map[string]interface{}{"entry": v, "error": err.Error()})
+			continue
+		}
+
+		list = append(list, records...)
 	}
 
+	return list, nil
+}
+
+// listDNSHostsEntries returns the raw dns.hosts entries
+func (c Client) listDNSHostsEntries(ctx context.Context) ([]string, error) {
 	req, err := c.RequestWithSession2(ctx, "GET", "/api/config/dns/hosts", nil)
 	if err != nil {
 		return nil, err
@@ -48,7 +181,7 @@ func (c Client) ListDNSRecords(ctx context.Context) (DNSRecordList, error) {
 		return nil, err
 	}
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to retrieve dns records, got status code %d", res.StatusCode)
+		return nil, fmt.Errorf("failed to retrieve dns records: %w", newAPIError(res))
 	}
 
 	defer res.Body.Close()
@@ -68,19 +201,37 @@ func (c Client) ListDNSRecords(ctx context.Context) (DNSRecordList, error) {
 		return nil, err
 	}
 
-	var list DNSRecordList
-	for _, v := range response.Config.DNS.Hosts {
-		splitted := strings.Split(v, " ")
-		if len(splitted) != 2 {
-			return nil, fmt.Errorf("failed to parse dns records")
-		}
-		list = append(list, pihole.DNSRecord{
-			IP:     splitted[0],
-			Domain: splitted[1],
-		})
+	return response.Config.DNS.Hosts, nil
+}
+
+// setDNSConfigArray replaces the dns.<key> config array with the passed entries in a single request
+func (c Client) setDNSConfigArray(ctx context.Context, key string, entries []string) error {
+	if entries == nil {
+		entries = []string{}
 	}
 
-	return list, nil
+	req, err := c.RequestWithSession2(ctx, "PATCH", "/api/config", map[string]any{
+		"config": map[string]any{
+			"dns": map[string]any{
+				key: entries,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode != 200 {
+		return fmt.Errorf("failed to set dns.%s: %w", key, newAPIError(res))
+	}
+
+	return nil
 }
 
 type CreateDNSRecordResponse struct {
@@ -90,42 +241,36 @@ type CreateDNSRecordResponse struct {
 
 // CreateDNSRecord creates a pihole DNS record entry
 func (c Client) CreateDNSRecord(ctx context.Context, record *DNSRecord) (*DNSRecord, error) {
-	if c.tokenClient != nil {
-		return c.tokenClient.LocalDNS.Create(ctx, record.Domain, record.IP)
+	c.dnsMu.Lock()
+	defer c.dnsMu.Unlock()
+
+	if err := c.addDNSHostsEntry(ctx, record.Entry()); err != nil {
+		return nil, err
 	}
 
-	cfg := strings.Join([]string{record.IP, record.Domain}, "%20")
-	req, err := c.RequestWithSession2(ctx, "PUT", fmt.Sprintf("/api/config/dns/hosts/%s", cfg), nil)
+	return record, nil
+}
+
+// addDNSHostsEntry adds an entry to dns.hosts
+func (c Client) addDNSHostsEntry(ctx context.Context, entry string) error {
+	req, err := c.RequestWithSession2(ctx, "PUT", fmt.Sprintf("/api/config/dns/hosts/%s", url.PathEscape(entry)), nil)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	res, err := c.client.Do(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	if res.StatusCode != 201 {
-		return nil, fmt.Errorf("failed to create dns records, got status code %d", res.StatusCode)
+		return fmt.Errorf("failed to create dns records: %w", newAPIError(res))
 	}
 
-	return record, nil
+	return nil
 }
 
 // GetDNSRecord searches the pihole local DNS records for the passed domain and returns a result if found
 func (c Client) GetDNSRecord(ctx context.Context, domain string) (*DNSRecord, error) {
-	if c.tokenClient != nil {
-		record, err := c.tokenClient.LocalDNS.Get(ctx, domain)
-		if err != nil {
-			if errors.Is(err, pihole.ErrorLocalDNSNotFound) {
-				return nil, NewNotFoundError(fmt.Sprintf("dns record with domain %q not found", domain))
-			}
-
-			return nil, err
-		}
-
-		return record, nil
-	}
-
 	list, err := c.ListDNSRecords(ctx)
 	if err != nil {
 		return nil, err
@@ -140,19 +285,31 @@ func (c Client) GetDNSRecord(ctx context.Context, domain string) (*DNSRecord, er
 	return nil, NewNotFoundError(fmt.Sprintf("record %q not found", domain))
 }
 
-// DeleteDNSRecord deletes a pihole local DNS record by domain name
+// DeleteDNSRecord deletes a pihole local DNS record by domain name. Other hostnames of the same dns.hosts entry are kept.
 func (c Client) DeleteDNSRecord(ctx context.Context, domain string) error {
-	if c.tokenClient != nil {
-		return c.tokenClient.LocalDNS.Delete(ctx, domain)
-	}
+	c.dnsMu.Lock()
+	defer c.dnsMu.Unlock()
 
-	record, err := c.GetDNSRecord(ctx, domain)
+	list, err := c.ListDNSRecords(ctx)
 	if err != nil {
 		return err
 	}
 
-	cfg := strings.Join([]string{record.IP, record.Domain}, "%20")
-	req, err := c.RequestWithSession2(ctx, "DELETE", fmt.Sprintf("/api/config/dns/hosts/%s", cfg), nil)
+	i := slices.IndexFunc(list, func(r DNSRecord) bool { return r.Domain == domain })
+	if i < 0 {
+		return NewNotFoundError(fmt.Sprintf("record %q not found", domain))
+	}
+	record := list[i]
+
+	// Add the remaining hostnames first, so they keep resolving while the original entry is removed
+	remaining := record.withoutHostname()
+	if remaining != "" && !slices.ContainsFunc(list, func(r DNSRecord) bool { return r.Entry() == remaining }) {
+		if err := c.addDNSHostsEntry(ctx, remaining); err != nil {
+			return err
+		}
+	}
+
+	req, err := c.RequestWithSession2(ctx, "DELETE", fmt.Sprintf("/api/config/dns/hosts/%s", url.PathEscape(record.Entry())), nil)
 	if err != nil {
 		return err
 	}
@@ -162,8 +319,61 @@ func (c Client) DeleteDNSRecord(ctx context.Context, domain string) error {
 		return err
 	}
 	if res.StatusCode != 204 {
-		return fmt.Errorf("failed to delete dns records, got status code %d", res.StatusCode)
+		return fmt.Errorf("failed to delete dns records: %w", newAPIError(res))
 	}
 
 	return nil
 }
+
+// UpdateDNSRecord changes the IP of the local DNS record of the passed domain. The whole dns.hosts array is replaced
+// in a single request, so that the domain keeps resolving during the change.
+func (c Client) UpdateDNSRecord(ctx context.Context, record *DNSRecord) (*DNSRecord, error) {
+	c.dnsMu.Lock()
+	defer c.dnsMu.Unlock()
+
+	entries, err := c.listDNSHostsEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := replaceDNSHostsEntry(entries, DNSRecord{IP: record.IP, Domain: record.Domain})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.setDNSConfigArray(ctx, "hosts", updated); err != nil {
+		return nil, fmt.Errorf("failed to update dns record %q: %w", record.Domain, err)
+	}
+
+	return record, nil
+}
+
+// replaceDNSHostsEntry returns a copy of the dns.hosts entries in which the domain of the record resolves to its IP.
+// Other hostnames of the entry holding the domain keep their IP, and the comment of the entry is kept.
+func replaceDNSHostsEntry(entries []string, record DNSRecord) ([]string, error) {
+	for i, entry := range entries {
+		records, err := parseDNSHosts(entry)
+		if err != nil {
+			continue
+		}
+
+		j := slices.IndexFunc(records, func(r DNSRecord) bool { return r.Domain == record.Domain })
+		if j < 0 {
+			continue
+		}
+
+		updated := slices.Clone(entries)
+
+		remaining := records[j].withoutHostname()
+		if remaining == "" {
+			updated[i] = records[j].withIP(record.IP)
+			return updated, nil
+		}
+
+		updated[i] = remaining
+
+		return slices.Insert(updated, i+1, record.Entry()), nil
+	}
+
+	return nil, NewNotFoundError(fmt.Sprintf("record %q not found", record.Domain))
+}
@@ -0,0 +1,171 @@
+package pihole
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseDNSHosts(t *testing.T) {
+	t.Run("Parse dns.hosts entries", func(t *testing.T) {
+		t.Parallel()
+
+		cases := map[string][]string{
+			"127.0.0.1 foo.lan":                      {"foo.lan"},
+			"192.168.1.10 nas nas.lan":               {"nas", "nas.lan"},
+			"  10.0.0.1\tgw\t gw.lan  router.lan ":   {"gw", "gw.lan", "router.lan"},
+			"2001:db8::10 printer.lan # office":      {"printer.lan"},
+			"fe80::1%eth0 link.lan":                  {"link.lan"},
+			"127.0.0.2 bar.lan#trailing comment baz": {"bar.lan"},
+		}
+
+		for entry, hostnames := range cases {
+			records, err := parseDNSHosts(entry)
+			require.NoError(t, err, entry)
+			require.Len(t, records, len(hostnames), entry)
+
+			ip := strings.Fields(entry)[0]
+			for i, record := range records {
+				require.Equal(t, ip, record.IP, entry)
+				require.Equal(t, hostnames[i], record.Domain, entry)
+				require.Equal(t, entry, record.Entry(), entry)
+			}
+		}
+	})
+
+	t.Run("Fail to parse entries without IP or hostname", func(t *testing.T) {
+		t.Parallel()
+
+		for _, entry := range []string{"", "   ", "# comment", "127.0.0.1", "127.0.0.1 # foo.lan", "foo.lan 127.0.0.1", "foo.lan"} {
+			_, err := parseDNSHosts(entry)
+			require.Error(t, err, entry)
+		}
+	})
+
+	t.Run("Format new entries", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, "127.0.0.1 foo.lan", DNSRecord{IP: "127.0.0.1", Domain: "foo.lan"}.Entry())
+	})
+
+	t.Run("Remove a hostname from its entry", func(t *testing.T) {
+		t.Parallel()
+
+		records, err := parseDNSHosts("10.0.0.1\tgw  gw.lan router.lan # gateway")
+		require.NoError(t, err)
+
+		require.Equal(t, "10.0.0.1  gw.lan router.lan # gateway", records[0].withoutHostname())
+		require.Equal(t, "10.0.0.1\tgw  gw.lan # gateway", records[2].withoutHostname())
+
+		records, err = parseDNSHosts("127.0.0.1 foo.lan")
+		require.NoError(t, err)
+		require.Empty(t, records[0].withoutHostname())
+	})
+}
+
+func FuzzParseDNSHosts(f *testing.F) {
+	for _, entry := range []string{
+		"127.0.0.1 foo.lan",
+		"192.168.1.10 nas nas.lan",
+		"  10.0.0.1\tgw\t gw.lan  router.lan ",
+		"2001:db8::10 printer.lan # office",
+		"# comment",
+		"127.0.0.1",
+		"",
+	} {
+		f.Add(entry)
+	}
+
+	f.Fuzz(func(t *testing.T, entry string) {
+		records, err := parseDNSHosts(entry)
+		if err != nil {
+			require.Empty(t, records)
+			return
+		}
+
+		require.NotEmpty(t, records)
+
+		domains := make([]string, 0, len(records))
+		for _, record := range records {
+			require.Equal(t, records[0].IP, record.IP)
+			require.NotEmpty(t, record.Domain)
+			require.NotContains(t, record.Domain, "#")
+			require.Len(t, strings.Fields(record.Domain), 1)
+			require.Equal(t, entry, record.Entry())
+
+			domains = append(domains, record.Domain)
+		}
+
+		// Formatting the records back into a single entry yields the same records
+		formatted, err := parseDNSHosts(records[0].IP + " " + strings.Join(domains, " "))
+		require.NoError(t, err)
+		require.Len(t, formatted, len(records))
+		for i, record := range formatted {
+			require.Equal(t, records[i].IP, record.IP)
+			require.Equal(t, records[i].Domain, record.Domain)
+		}
+
+		// Changing the IP keeps the hostnames of the entry
+		moved, err := parseDNSHosts(records[0].withIP("10.0.0.1"))
+		require.NoError(t, err)
+		require.Len(t, moved, len(records))
+		for i, record := range moved {
+			require.Equal(t, "10.0.0.1", record.IP)
+			require.Equal(t, records[i].Domain, record.Domain)
+		}
+
+		// Removing a hostname keeps the other hostnames of the entry
+		remaining := records[0].withoutHostname()
+		if remaining == "" {
+			for _, record := range records {
+				require.Equal(t, records[0].Domain, record.Domain)
+			}
+			return
+		}
+
+		rest, err := parseDNSHosts(remaining)
+		require.NoError(t, err)
+		for _, record := range rest {
+			require.Equal(t, records[0].IP, record.IP)
+			require.NotEqual(t, records[0].Domain, record.Domain)
+		}
+	})
+}
+
+func TestReplaceDNSHostsEntry(t *testing.T) {
+	t.Run("Replace an entry in place", func(t *testing.T) {
+		t.Parallel()
+
+		updated, err := replaceDNSHostsEntry([]string{"127.0.0.1 foo.lan", "127.0.0.2 bar.lan"}, DNSRecord{IP: "10.0.0.1", Domain: "foo.lan"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"10.0.0.1 foo.lan", "127.0.0.2 bar.lan"}, updated)
+	})
+
+	t.Run("Keep the comment of an entry", func(t *testing.T) {
+		t.Parallel()
+
+		updated, err := replaceDNSHostsEntry([]string{" 127.0.0.1\tfoo.lan  # office printer", "127.0.0.2 bar.lan"}, DNSRecord{IP: "10.0.0.1", Domain: "foo.lan"})
+		require.NoError(t, err)
+		require.Equal(t, []string{" 10.0.0.1\tfoo.lan  # office printer", "127.0.0.2 bar.lan"}, updated)
+
+		updated, err = replaceDNSHostsEntry([]string{"127.0.0.1 foo.lan nas.lan # office"}, DNSRecord{IP: "10.0.0.1", Domain: "nas.lan"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"127.0.0.1 foo.lan # office", "10.0.0.1 nas.lan"}, updated)
+	})
+
+	t.Run("Keep the other hostnames of an entry", func(t *testing.T) {
+		t.Parallel()
+
+		updated, err := replaceDNSHostsEntry([]string{"127.0.0.1 foo.lan\tnas.lan", "127.0.0.2 bar.lan"}, DNSRecord{IP: "10.0.0.1", Domain: "nas.lan"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"127.0.0.1 foo.lan", "10.0.0.1 nas.lan", "127.0.0.2 bar.lan"}, updated)
+	})
+
+	t.Run("Fail if the domain is not found", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := replaceDNSHostsEntry([]string{"127.0.0.1 foo.lan", "not-an-ip bar.lan"}, DNSRecord{IP: "10.0.0.1", Domain: "bar.lan"})
+		require.ErrorAs(t, err, new(*NotFoundError))
+	})
+}
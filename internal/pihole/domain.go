@@ -4,30 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"net/url"
 	"time"
 )
 
 type ListDomainsOptions struct {
 	Type string
-}
-
-const (
-	// DomainTypeAllow indicates a domain that is added to the allow list
-	DomainTypeAllowExact int = 0
-	// DomainTypeDeny indicates a domain that is added to the deny list
-	DomainTypeDenyExact int = 1
-	// DomainTypeAllowWildcard indicates a wildcard domain added to the allow list
-	DomainTypeAllowWildcard int = 2
-	// DomainTypeDenyWildcard indicates a wildcard domain added to the deny list
-	DomainTypeDenyWildcard int = 3
-)
-
-var IntToDomainType = map[int]string{
-	DomainTypeAllowExact:    DomainOptionsAllow,
-	DomainTypeDenyExact:     DomainOptionsDeny,
-	DomainTypeAllowWildcard: DomainOptionsAllow,
-	DomainTypeDenyWildcard:  DomainOptionsDeny,
+	Kind string
 }
 
 const (
@@ -37,25 +21,40 @@ const (
 	DomainOptionsDeny string = "deny"
 )
 
+const (
+	// DomainKindExact is a domain matched literally
+	DomainKindExact string = "exact"
+	// DomainKindRegex is a domain interpreted as a regular expression
+	DomainKindRegex string = "regex"
+)
+
 type DomainResponseList struct {
-	Data []*DomainResponse
+	Domains   []DomainResponse `json:"domains"`
+	Processed *struct {
+		Errors []struct {
+			Item    string `json:"item"`
+			Message string `json:"error"`
+		} `json:"errors"`
+	} `json:"processed"`
 }
 
 type DomainResponse struct {
 	ID           int64   `json:"id"`
-	Type         int     `json:"type"`
-	Enabled      int     `json:"enabled"`
 	Domain       string  `json:"domain"`
-	Comment      string  `json:"comment"`
+	Type         string  `json:"type"`
+	Kind         string  `json:"kind"`
+	Comment      *string `json:"comment"`
+	Groups       []int64 `json:"groups"`
+	Enabled      bool    `json:"enabled"`
 	DateAdded    int64   `json:"date_added"`
 	DateModified int64   `json:"date_modified"`
-	Groups       []int64 `json:"groups"`
 }
 
+// ToDomainList converts a DomainResponseList to a DomainList
 func (l DomainResponseList) ToDomainList() DomainList {
-	list := make(DomainList, len(l.Data))
+	list := make(DomainList, len(l.Domains))
 
-	for i, d := range l.Data {
+	for i, d := range l.Domains {
 		list[i] = d.ToDomain()
 	}
 
@@ -76,41 +75,144 @@ type Domain struct {
 
 type DomainList []*Domain
 
+// ToDomain converts a DomainResponse to a Domain
 func (d DomainResponse) ToDomain() *Domain {
+	comment := ""
+	if d.Comment != nil {
+		comment = *d.Comment
+	}
+
 	return &Domain{
 		ID:           d.ID,
-		Type:         IntToDomainType[d.Type],
-		Enabled:      d.Enabled == 1,
+		Type:         d.Type,
+		Enabled:      d.Enabled,
 		Domain:       d.Domain,
-		Comment:      d.Comment,
+		Comment:      comment,
 		DateAdded:    time.Unix(d.DateAdded, 0),
 		DateModified: time.Unix(d.DateModified, 0),
-		Wildcard:     d.Type == DomainTypeAllowWildcard || d.Type == DomainTypeDenyWildcard,
+		Wildcard:     d.Kind == DomainKindRegex,
 		GroupIDs:     d.Groups,
 	}
 }
 
 // ListDomains returns a list of domains
 func (c Client) ListDomains(ctx context.Context, opts ListDomainsOptions) (DomainList, error) {
-	if c.tokenClient != nil {
-		return nil, fmt.Errorf("%w: list domains", ErrNotImplementedTokenClient)
+	path := "/api/domains"
+
+	if opts.Type != "" {
+		if !validDomainType(opts.Type) {
+			return nil, fmt.Errorf("unknown type passed to ListDomains: %s", opts.Type)
+		}
+
+		path = fmt.Sprintf("%s/%s", path, opts.Type)
 	}
 
-	values := &url.Values{
-		"action": []string{"get_domains"},
+	if opts.Kind != "" && opts.Kind != DomainKindExact && opts.Kind != DomainKindRegex {
+		return nil, fmt.Errorf("unknown kind passed to ListDomains: %s", opts.Kind)
 	}
 
-	if opts.Type != "" {
-		if opts.Type == DomainOptionsAllow {
-			values.Add("showtype", "white")
-		} else if opts.Type == DomainOptionsDeny {
-			values.Add("showtype", "black")
-		} else {
-			return nil, fmt.Errorf("unknown type passed to ListDomains: %s", opts.Type)
+	req, err := c.RequestWithSession2(ctx, "GET", path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to retrieve domains: %w", newAPIError(res))
+	}
+
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	var response DomainResponseList
+	if err := json.Unmarshal(b, &response); err != nil {
+		return nil, err
+	}
+
+	list := DomainList{}
+	for _, d := range response.Domains {
+		if opts.Kind != "" && d.Kind != opts.Kind {
+			continue
+		}
+
+		list = append(list, d.ToDomain())
+	}
+
+	return list, nil
+}
+
+type DomainCreateRequest struct {
+	Domain   string
+	Type     string
+	Wildcard bool
+	Comment  string
+	Enabled  *bool
+	GroupIDs []int64
+}
+
+type DomainUpdateRequest struct {
+	Domain   string
+	Type     string
+	Wildcard bool
+	Comment  string
+	Enabled  *bool
+	GroupIDs []int64
+}
+
+// domainKind returns the v6 API kind corresponding to the wildcard flag
+func domainKind(wildcard bool) string {
+	if wildcard {
+		return DomainKindRegex
+	}
+
+	return DomainKindExact
+}
+
+// validDomainType indicates whether the passed domain type is supported
+func validDomainType(domainType string) bool {
+	return domainType == DomainOptionsAllow || domainType == DomainOptionsDeny
+}
+
+// GetDomainByID returns a Pi-hole domain by ID
+func (c Client) GetDomainByID(ctx context.Context, id int64) (*Domain, error) {
+	domains, err := c.ListDomains(ctx, ListDomainsOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, d := range domains {
+		if d.ID == id {
+			return d, nil
 		}
 	}
 
-	req, err := c.RequestWithSession(ctx, "POST", "/admin/scripts/pi-hole/php/groups.php", values)
+	return nil, NewNotFoundError(fmt.Sprintf("domain with ID %d not found", id))
+}
+
+// CreateDomain adds a domain to the allow or deny list
+func (c Client) CreateDomain(ctx context.Context, dr *DomainCreateRequest) (*Domain, error) {
+	if !validDomainType(dr.Type) {
+		return nil, fmt.Errorf("unknown domain type: %s", dr.Type)
+	}
+
+	data := map[string]any{
+		"domain":  dr.Domain,
+		"comment": dr.Comment,
+	}
+	if dr.Enabled != nil {
+		data["enabled"] = *dr.Enabled
+	}
+	if dr.GroupIDs != nil {
+		data["groups"] = dr.GroupIDs
+	}
+
+	path := fmt.Sprintf("/api/domains/%s/%s", dr.Type, domainKind(dr.Wildcard))
+	req, err := c.RequestWithSession2(ctx, "POST", path, data)
 	if err != nil {
 		return nil, err
 	}
@@ -119,13 +221,100 @@ func (c Client) ListDomains(ctx context.Context, opts ListDomainsOptions) (Domai
 	if err != nil {
 		return nil, err
 	}
+	if res.StatusCode != 201 {
+		return nil, fmt.Errorf("failed to create domain: %w", newAPIError(res))
+	}
 
 	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	var response DomainResponseList
+	if err := json.Unmarshal(b, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Processed != nil && len(response.Processed.Errors) > 0 {
+		return nil, fmt.Errorf("failed to create domain %q: %s", dr.Domain, response.Processed.Errors[0].Message)
+	}
+
+	if len(response.Domains) != 1 {
+		return nil, fmt.Errorf("failed to create domain %q, got %d domains in response", dr.Domain, len(response.Domains))
+	}
+
+	return response.Domains[0].ToDomain(), nil
+}
 
-	var domainRes DomainResponseList
-	if err = json.NewDecoder(res.Body).Decode(&domainRes); err != nil {
+// UpdateDomain updates the comment, enabled state and groups of a domain
+func (c Client) UpdateDomain(ctx context.Context, dr *DomainUpdateRequest) (*Domain, error) {
+	if !validDomainType(dr.Type) {
+		return nil, fmt.Errorf("unknown domain type: %s", dr.Type)
+	}
+
+	kind := domainKind(dr.Wildcard)
+	data := map[string]any{
+		"type":    dr.Type,
+		"kind":    kind,
+		"comment": dr.Comment,
+	}
+	if dr.Enabled != nil {
+		data["enabled"] = *dr.Enabled
+	}
+	if dr.GroupIDs != nil {
+		data["groups"] = dr.GroupIDs
+	}
+
+	path := fmt.Sprintf("/api/domains/%s/%s/%s", dr.Type, kind, url.PathEscape(dr.Domain))
+	req, err := c.RequestWithSession2(ctx, "PUT", path, data)
+	if err != nil {
 		return nil, err
 	}
 
-	return domainRes.ToDomainList(), nil
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to update domain: %w", newAPIError(res))
+	}
+
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	var response DomainResponseList
+	if err := json.Unmarshal(b, &response); err != nil {
+		return nil, err
+	}
+
+	if len(response.Domains) != 1 {
+		return nil, fmt.Errorf("failed to update domain %q, got %d domains in response", dr.Domain, len(response.Domains))
+	}
+
+	return response.Domains[0].ToDomain(), nil
+}
+
+// DeleteDomain removes a domain from the allow or deny list
+func (c Client) DeleteDomain(ctx context.Context, domainType string, wildcard bool, domain string) error {
+	if !validDomainType(domainType) {
+		return fmt.Errorf("unknown domain type: %s", domainType)
+	}
+
+	path := fmt.Sprintf("/api/domains/%s/%s/%s", domainType, domainKind(wildcard), url.PathEscape(domain))
+	req, err := c.RequestWithSession2(ctx, "DELETE", path, nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != 204 {
+		return fmt.Errorf("failed to delete domain: %w", newAPIError(res))
+	}
+
+	return nil
 }
@@ -1,6 +1,13 @@
 package pihole
 
-import "errors"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
 
 type NotFoundError struct {
 	err string
@@ -21,11 +28,97 @@ func (e *NotFoundError) Error() string {
 	return e.err
 }
 
+// APIError is returned when Pi-hole answers a request with an unexpected status code, carrying the details of the
+// error body returned by the v6 API
+type APIError struct {
+	StatusCode int
+	Key        string
+	Message    string
+	Hint       string
+	Method     string
+	Path       string
+}
+
+// maxAPIErrorBodySize limits how much of an unexpected response is read to build an APIError
+const maxAPIErrorBodySize = 64 << 10
+
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("%s %s returned status code %d", e.Method, e.Path, e.StatusCode)
+
+	if e.Key != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Key)
+	}
+
+	if e.Message != "" {
+		msg = fmt.Sprintf("%s: %s", msg, e.Message)
+	}
+
+	if e.Hint != "" {
+		msg = fmt.Sprintf("%s (%s)", msg, e.Hint)
+	}
+
+	return msg
+}
+
+// newAPIError reads and closes the body of an unexpected response and returns the APIError it describes
+func newAPIError(res *http.Response) *APIError {
+	b, _ := io.ReadAll(io.LimitReader(res.Body, maxAPIErrorBodySize))
+	res.Body.Close()
+
+	return parseAPIError(res, b)
+}
+
+// parseAPIError returns the APIError described by the already read body of an unexpected response
+func parseAPIError(res *http.Response, body []byte) *APIError {
+	apiErr := &APIError{
+		StatusCode: res.StatusCode,
+	}
+
+	if res.Request != nil {
+		apiErr.Method = res.Request.Method
+		apiErr.Path = res.Request.URL.Path
+	}
+
+	type Response struct {
+		Error struct {
+			Key     string `json:"key"`
+			Message string `json:"message"`
+			Hint    any    `json:"hint"`
+		} `json:"error"`
+	}
+
+	var response Response
+	if err := json.Unmarshal(body, &response); err != nil {
+		// Proxies in front of Pi-hole answer with plain text or HTML error pages
+		apiErr.Message = http.StatusText(res.StatusCode)
+
+		return apiErr
+	}
+
+	apiErr.Key = response.Error.Key
+	apiErr.Message = response.Error.Message
+
+	// The hint is null or a string for most errors but some endpoints return structured details
+	switch hint := response.Error.Hint.(type) {
+	case nil:
+	case string:
+		apiErr.Hint = hint
+	default:
+		if b, err := json.Marshal(hint); err == nil {
+			apiErr.Hint = string(b)
+		}
+	}
+
+	apiErr.Hint = strings.TrimSpace(apiErr.Hint)
+
+	return apiErr
+}
+
 var (
 	// ErrLoginFailed is returned when a login attempt fails
 	ErrLoginFailed = errors.New("login failed")
 	// ErrClientValidationFailed
 	ErrClientValidationFailed = errors.New("client validation failed")
-	// ErrNotImplementedTokenClient is returned when a particular Pi-hole resource cannot be managed due to missing client configuration
-	ErrNotImplementedTokenClient = errors.New("resource is not implemented for the API token client")
+	// ErrAppSudoDisabled is returned when writing with an application password while webserver.api.app_sudo is disabled
+	ErrAppSudoDisabled = errors.New("application password is read-only, enable webserver.api.app_sudo in the Pi-hole settings to allow changes")
 )
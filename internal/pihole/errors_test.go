@@ -0,0 +1,88 @@
+package pihole
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAPIError(t *testing.T) {
+	res := func(statusCode int) *http.Response {
+		return &http.Response{
+			StatusCode: statusCode,
+			Request: &http.Request{
+				Method: http.MethodPost,
+				URL:    &url.URL{Path: "/api/domains/deny/exact"},
+			},
+		}
+	}
+
+	t.Run("Parse the v6 error body", func(t *testing.T) {
+		t.Parallel()
+
+		err := parseAPIError(res(400), []byte(`{"error":{"key":"bad_request","message":"Invalid request","hint":"domain already exists"},"took":0.001}`))
+
+		require.Equal(t, &APIError{
+			StatusCode: 400,
+			Key:        "bad_request",
+			Message:    "Invalid request",
+			Hint:       "domain already exists",
+			Method:     http.MethodPost,
+			Path:       "/api/domains/deny/exact",
+		}, err)
+		require.Equal(t, "POST /api/domains/deny/exact returned status code 400: bad_request: Invalid request (domain already exists)", err.Error())
+	})
+
+	t.Run("Omit a null hint", func(t *testing.T) {
+		t.Parallel()
+
+		err := parseAPIError(res(404), []byte(`{"error":{"key":"not_found","message":"Item not found","hint":null}}`))
+
+		require.Equal(t, "POST /api/domains/deny/exact returned status code 404: not_found: Item not found", err.Error())
+	})
+
+	t.Run("Encode a structured hint", func(t *testing.T) {
+		t.Parallel()
+
+		err := parseAPIError(res(400), []byte(`{"error":{"key":"bad_request","message":"Invalid regex","hint":{"item":"(","error":"Missing ')'"}}}`))
+
+		require.Equal(t, `{"error":"Missing ')'","item":"("}`, err.Hint)
+	})
+
+	t.Run("Fall back to the status text for other bodies", func(t *testing.T) {
+		t.Parallel()
+
+		err := parseAPIError(res(502), []byte(`<html>Bad Gateway</html>`))
+
+		require.Equal(t, "POST /api/domains/deny/exact returned status code 502: Bad Gateway", err.Error())
+	})
+}
+
+func TestAPIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/auth" {
+			w.Write([]byte(`{"session":{"valid":true,"sid":"sid","csrf":"csrf","validity":300}}`)) //nolint:errcheck
+			return
+		}
+
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error":{"key":"bad_request","message":"Invalid request","hint":"UNIQUE constraint failed: group.name"}}`)) //nolint:errcheck
+	}))
+	defer server.Close()
+
+	client := New(Config{Password: "test", URL: server.URL})
+
+	_, err := client.CreateGroup(context.Background(), &GroupCreateRequest{Name: "test"})
+
+	var apiErr *APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+	require.Equal(t, "bad_request", apiErr.Key)
+	require.Equal(t, "/api/groups", apiErr.Path)
+	require.Equal(t, "failed to create group: POST /api/groups returned status code 400: bad_request: Invalid request (UNIQUE constraint failed: group.name)", err.Error())
+}
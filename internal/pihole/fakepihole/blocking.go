@@ -0,0 +1,70 @@
+package fakepihole
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// blockingStatus returns the blocking status, toggling it back once its timer elapsed, the caller must hold the lock
+func (s *Server) blockingStatus(now time.Time) map[string]any {
+	if !s.blockingUntil.IsZero() && !now.Before(s.blockingUntil) {
+		s.blocking = !s.blocking
+		s.blockingUntil = time.Time{}
+	}
+
+	status := "disabled"
+	if s.blocking {
+		status = "enabled"
+	}
+
+	var timer any
+	if !s.blockingUntil.IsZero() {
+		timer = s.blockingUntil.Sub(now).Seconds()
+	}
+
+	return map[string]any{
+		"blocking": status,
+		"timer":    timer,
+	}
+}
+
+func (s *Server) handleGetBlocking(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	writeJSON(w, http.StatusOK, s.blockingStatus(time.Now()))
+}
+
+func (s *Server) handleSetBlocking(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Blocking *bool    `json:"blocking"`
+		Timer    *float64 `json:"timer"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid JSON payload", err.Error())
+		return
+	}
+
+	if body.Blocking == nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "No \"blocking\" boolean in body data", nil)
+		return
+	}
+
+	if body.Timer != nil && *body.Timer < 0 {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid \"timer\" value", "Timer must be a non-negative number")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	s.blocking = *body.Blocking
+	s.blockingUntil = time.Time{}
+	if body.Timer != nil && *body.Timer > 0 {
+		s.blockingUntil = now.Add(time.Duration(*body.Timer * float64(time.Second)))
+	}
+
+	writeJSON(w, http.StatusOK, s.blockingStatus(now))
+}
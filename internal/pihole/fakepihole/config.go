@@ -0,0 +1,230 @@
+package fakepihole
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// configArray is a config array Pi-hole serves under /api/config/<section>/<key>
+type configArray struct {
+	items    []string
+	validate func(string) error
+}
+
+// newConfigArrays returns the empty config arrays the stand-in serves
+func newConfigArrays() map[string]*configArray {
+	return map[string]*configArray{
+		"dns.hosts":        {validate: validateHost},
+		"dns.cnameRecords": {validate: validateCNAMERecord},
+		"dns.upstreams":    {validate: validateUpstream},
+		"dns.revServers":   {validate: validateRevServer},
+		"dhcp.hosts":       {validate: validateDHCPHost},
+	}
+}
+
+// validateUpstream checks a dns.upstreams entry of the form "IP[#port]"
+func validateUpstream(entry string) error {
+	addr, port, hasPort := strings.Cut(entry, "#")
+
+	if _, err := netip.ParseAddr(addr); err != nil {
+		return fmt.Errorf("dns.upstreams: %q: neither a valid IPv4 nor IPv6 address", addr)
+	}
+
+	if p, err := strconv.Atoi(port); hasPort && (err != nil || p < 1 || p > 65535) {
+		return fmt.Errorf("dns.upstreams: %q: invalid port", port)
+	}
+
+	return nil
+}
+
+// validateRevServer checks a dns.revServers entry of the form "active,CIDR,server[#port],domain"
+func validateRevServer(entry string) error {
+	fields := strings.Split(entry, ",")
+	if len(fields) != 4 {
+		return fmt.Errorf("dns.revServers: %q is not of the form \"<enabled>,<ip-address>[/<prefix-len>],<server>[#<port>],<domain>\"", entry)
+	}
+
+	if _, err := strconv.ParseBool(fields[0]); err != nil {
+		return fmt.Errorf("dns.revServers: %q: invalid enabled value", fields[0])
+	}
+
+	if _, err := netip.ParsePrefix(fields[1]); err != nil {
+		return fmt.Errorf("dns.revServers: %q: invalid CIDR", fields[1])
+	}
+
+	return validateUpstream(fields[2])
+}
+
+// validateDHCPHost checks that a dhcp.hosts entry is not empty, Pi-hole accepts any dnsmasq dhcp-host entry
+func validateDHCPHost(entry string) error {
+	if strings.TrimSpace(entry) == "" {
+		return fmt.Errorf("dhcp.hosts: empty entry")
+	}
+
+	return nil
+}
+
+// configItems returns the entries of the config array with the passed dotted path
+func (s *Server) configItems(path string) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return slices.Clone(s.config[path].items)
+}
+
+// setConfigItems replaces the entries of the config array with the passed dotted path
+func (s *Server) setConfigItems(path string, items []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.config[path].items = slices.Clone(items)
+}
+
+// configArray returns the config array of the request path, writing a not found response if there is none
+func (s *Server) configArray(w http.ResponseWriter, r *http.Request) (*configArray, bool) {
+	array, ok := s.config[r.PathValue("section")+"."+r.PathValue("key")]
+	if !ok {
+		writeNotFound(w)
+	}
+
+	return array, ok
+}
+
+func (s *Server) handleListConfigItems(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	array, ok := s.configArray(w, r)
+	if !ok {
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"config": map[string]any{
+			r.PathValue("section"): map[string]any{
+				r.PathValue("key"): nonNil(array.items),
+			},
+		},
+	})
+}
+
+// handleAddConfigItem appends an item to a config array, answering like PUT /api/config/<section>/<key>/<item>
+func (s *Server) handleAddConfigItem(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	array, ok := s.configArray(w, r)
+	if !ok {
+		return
+	}
+
+	item := r.PathValue("entry")
+	if err := array.validate(item); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid value", err.Error())
+		return
+	}
+
+	if slices.Contains(array.items, item) {
+		writeError(w, http.StatusBadRequest, "bad_request", "Item already present", "Uniqueness of items is enforced")
+		return
+	}
+
+	array.items = append(array.items, item)
+
+	writeJSON(w, http.StatusCreated, map[string]any{})
+}
+
+// handleDeleteConfigItem removes an item from a config array, answering like DELETE /api/config/<section>/<key>/<item>
+func (s *Server) handleDeleteConfigItem(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	array, ok := s.configArray(w, r)
+	if !ok {
+		return
+	}
+
+	i := slices.Index(array.items, r.PathValue("entry"))
+	if i < 0 {
+		writeNotFound(w)
+		return
+	}
+
+	array.items = slices.Delete(array.items, i, i+1)
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// handlePatchConfig replaces the config arrays present in the body, other config keys are rejected as the stand-in
+// does not serve them
+func (s *Server) handlePatchConfig(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Config map[string]map[string]json.RawMessage `json:"config"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid request body data", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Validate every array before changing any, Pi-hole applies a patch as a whole
+	patched := map[string][]string{}
+	for section, keys := range body.Config {
+		for key, raw := range keys {
+			path := section + "." + key
+
+			array, ok := s.config[path]
+			if !ok {
+				writeError(w, http.StatusBadRequest, "bad_request", "Config item is invalid", path)
+				return
+			}
+
+			var items []string
+			if err := json.Unmarshal(raw, &items); err != nil {
+				writeError(w, http.StatusBadRequest, "bad_request", "Config item is invalid", fmt.Sprintf("%s: %s", path, err))
+				return
+			}
+
+			for _, item := range items {
+				if err := array.validate(item); err != nil {
+					writeError(w, http.StatusBadRequest, "bad_request", "Config item validation failed", err.Error())
+					return
+				}
+			}
+
+			patched[path] = items
+		}
+	}
+
+	response := map[string]any{}
+	for path, items := range patched {
+		// Pi-hole replaces config arrays as a whole, in the order they are passed
+		s.config[path].items = slices.Clone(items)
+
+		section, key, _ := strings.Cut(path, ".")
+		if response[section] == nil {
+			response[section] = map[string]any{}
+		}
+		response[section].(map[string]any)[key] = nonNil(items)
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"config": response,
+	})
+}
+
+// nonNil returns an empty slice in place of nil, so that it is encoded as an empty JSON array
+func nonNil(items []string) []string {
+	if items == nil {
+		return []string{}
+	}
+
+	return items
+}
@@ -0,0 +1,76 @@
+package fakepihole
+
+import (
+	"fmt"
+	"net/netip"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var validHostname = regexp.MustCompile(`^([A-Za-z0-9_]([A-Za-z0-9_-]{0,61}[A-Za-z0-9])?)(\.[A-Za-z0-9_]([A-Za-z0-9_-]{0,61}[A-Za-z0-9])?)*\.?$`)
+
+// validateHost checks a dns.hosts entry of the form "IP hostname [hostname...]"
+func validateHost(entry string) error {
+	line, _, _ := strings.Cut(entry, "#")
+
+	fields := strings.Fields(line)
+	if len(fields) < 2 {
+		return fmt.Errorf("dns.hosts: %q is not of the form \"IP HOSTNAME\"", entry)
+	}
+
+	if _, err := netip.ParseAddr(fields[0]); err != nil {
+		return fmt.Errorf("dns.hosts: %q: neither a valid IPv4 nor IPv6 address", fields[0])
+	}
+
+	for _, hostname := range fields[1:] {
+		if !validHostname.MatchString(hostname) {
+			return fmt.Errorf("dns.hosts: %q: invalid hostname", hostname)
+		}
+	}
+
+	return nil
+}
+
+// validateCNAMERecord checks a dns.cnameRecords entry of the form "domain[,domain...],target[,TTL]"
+func validateCNAMERecord(entry string) error {
+	fields := strings.Split(entry, ",")
+
+	if len(fields) > 2 {
+		if _, err := strconv.ParseUint(fields[len(fields)-1], 10, 32); err == nil {
+			fields = fields[:len(fields)-1]
+		}
+	}
+
+	if len(fields) < 2 {
+		return fmt.Errorf("dns.cnameRecords: %q is not of the form \"DOMAIN,TARGET[,TTL]\"", entry)
+	}
+
+	for _, name := range fields {
+		if !validHostname.MatchString(name) {
+			return fmt.Errorf("dns.cnameRecords: %q: invalid domain", name)
+		}
+	}
+
+	return nil
+}
+
+// Hosts returns the dns.hosts entries
+func (s *Server) Hosts() []string {
+	return s.configItems("dns.hosts")
+}
+
+// CNAMERecords returns the dns.cnameRecords entries
+func (s *Server) CNAMERecords() []string {
+	return s.configItems("dns.cnameRecords")
+}
+
+// SetHosts replaces the dns.hosts entries, e.g. to seed entries the provider does not manage
+func (s *Server) SetHosts(hosts []string) {
+	s.setConfigItems("dns.hosts", hosts)
+}
+
+// SetCNAMERecords replaces the dns.cnameRecords entries
+func (s *Server) SetCNAMERecords(records []string) {
+	s.setConfigItems("dns.cnameRecords", records)
+}
@@ -0,0 +1,256 @@
+package fakepihole
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"regexp"
+	"slices"
+	"time"
+)
+
+type domain struct {
+	ID           int64   `json:"id"`
+	Domain       string  `json:"domain"`
+	Unicode      string  `json:"unicode"`
+	Type         string  `json:"type"`
+	Kind         string  `json:"kind"`
+	Comment      *string `json:"comment"`
+	Groups       []int64 `json:"groups"`
+	Enabled      bool    `json:"enabled"`
+	DateAdded    int64   `json:"date_added"`
+	DateModified int64   `json:"date_modified"`
+}
+
+// domainRequest is the body of POST /api/domains/<type>/<kind> and PUT /api/domains/<type>/<kind>/<domain>
+type domainRequest struct {
+	Domain  any     `json:"domain"`
+	Type    *string `json:"type"`
+	Kind    *string `json:"kind"`
+	Comment *string `json:"comment"`
+	Groups  []int64 `json:"groups"`
+	Enabled *bool   `json:"enabled"`
+}
+
+// validDomainList indicates whether the type and kind path segments name a domain list
+func validDomainList(domainType string, kind string) bool {
+	return (domainType == "allow" || domainType == "deny") && (kind == "exact" || kind == "regex")
+}
+
+// validateDomain checks a domain of the passed kind the way Pi-hole does before adding it
+func validateDomain(name string, kind string) error {
+	if kind == "regex" {
+		if _, err := regexp.Compile(name); err != nil {
+			return fmt.Errorf("Invalid regex: %s", err)
+		}
+
+		return nil
+	}
+
+	if !validHostname.MatchString(name) {
+		return fmt.Errorf("Invalid domain")
+	}
+
+	return nil
+}
+
+// findDomain returns the index of the domain on the passed list, the caller must hold the lock
+func (s *Server) findDomain(domainType string, kind string, name string) int {
+	for i, d := range s.domains {
+		if d.Type == domainType && d.Kind == kind && d.Domain == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (s *Server) handleListDomains(w http.ResponseWriter, r *http.Request) {
+	domainType, kind, name := r.PathValue("type"), r.PathValue("kind"), r.PathValue("domain")
+
+	if (domainType != "" && domainType != "allow" && domainType != "deny") || (kind != "" && kind != "exact" && kind != "regex") {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid request: Specify list to modify more precisely", r.URL.Path)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	domains := []*domain{}
+	for _, d := range s.domains {
+		if (domainType == "" || d.Type == domainType) && (kind == "" || d.Kind == kind) && (name == "" || d.Domain == name) {
+			domains = append(domains, d)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"domains": domains,
+	})
+}
+
+func (s *Server) handleAddDomain(w http.ResponseWriter, r *http.Request) {
+	domainType, kind := r.PathValue("type"), r.PathValue("kind")
+	if !validDomainList(domainType, kind) {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid request: Specify list to modify more precisely", r.URL.Path)
+		return
+	}
+
+	var body domainRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid JSON payload", err.Error())
+		return
+	}
+
+	// The domain is either a single string or an array of strings
+	var names []string
+	switch v := body.Domain.(type) {
+	case string:
+		names = []string{v}
+	case []any:
+		for _, item := range v {
+			name, ok := item.(string)
+			if !ok {
+				names = nil
+				break
+			}
+
+			names = append(names, name)
+		}
+	}
+
+	if len(names) == 0 {
+		writeError(w, http.StatusBadRequest, "bad_request", "No \"domain\" string or array in body data", nil)
+		return
+	}
+
+	groups := body.Groups
+	if groups == nil {
+		groups = []int64{0}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now().Unix()
+	created := []*domain{}
+	success := []string{}
+	errors := []map[string]string{}
+
+	for _, name := range names {
+		if err := validateDomain(name, kind); err != nil {
+			errors = append(errors, map[string]string{"item": name, "error": err.Error()})
+			continue
+		}
+
+		if s.findDomain(domainType, kind, name) >= 0 {
+			errors = append(errors, map[string]string{"item": name, "error": "UNIQUE constraint failed: domainlist.domain, domainlist.type"})
+			continue
+		}
+
+		d := &domain{
+			ID:           s.nextDomainID,
+			Domain:       name,
+			Unicode:      name,
+			Type:         domainType,
+			Kind:         kind,
+			Comment:      body.Comment,
+			Groups:       slices.Clone(groups),
+			Enabled:      body.Enabled == nil || *body.Enabled,
+			DateAdded:    now,
+			DateModified: now,
+		}
+		s.nextDomainID++
+		s.domains = append(s.domains, d)
+
+		created = append(created, d)
+		success = append(success, name)
+	}
+
+	p := processed(success, "", "")
+	p["errors"] = errors
+
+	writeJSON(w, http.StatusCreated, map[string]any{
+		"domains":   created,
+		"processed": p,
+	})
+}
+
+func (s *Server) handleUpdateDomain(w http.ResponseWriter, r *http.Request) {
+	domainType, kind, name := r.PathValue("type"), r.PathValue("kind"), r.PathValue("domain")
+	if !validDomainList(domainType, kind) {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid request: Specify list to modify more precisely", r.URL.Path)
+		return
+	}
+
+	var body domainRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid JSON payload", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.findDomain(domainType, kind, name)
+	if i < 0 {
+		writeNotFound(w)
+		return
+	}
+
+	d := s.domains[i]
+
+	// The type and kind in the body move the domain to another list
+	newType, newKind := d.Type, d.Kind
+	if body.Type != nil {
+		newType = *body.Type
+	}
+	if body.Kind != nil {
+		newKind = *body.Kind
+	}
+
+	if !validDomainList(newType, newKind) {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid request: Specify list to modify more precisely", fmt.Sprintf("%s/%s", newType, newKind))
+		return
+	}
+
+	if (newType != d.Type || newKind != d.Kind) && s.findDomain(newType, newKind, name) >= 0 {
+		writeError(w, http.StatusBadRequest, "database_error", "Could not replace item in gravity database", "UNIQUE constraint failed: domainlist.domain, domainlist.type")
+		return
+	}
+
+	d.Type, d.Kind = newType, newKind
+	d.Comment = body.Comment
+	if body.Groups != nil {
+		d.Groups = slices.Clone(body.Groups)
+	}
+	if body.Enabled != nil {
+		d.Enabled = *body.Enabled
+	}
+	d.DateModified = time.Now().Unix()
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"domains":   []*domain{d},
+		"processed": processed([]string{d.Domain}, "", ""),
+	})
+}
+
+func (s *Server) handleDeleteDomain(w http.ResponseWriter, r *http.Request) {
+	domainType, kind, name := r.PathValue("type"), r.PathValue("kind"), r.PathValue("domain")
+	if !validDomainList(domainType, kind) {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid request: Specify list to modify more precisely", r.URL.Path)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.findDomain(domainType, kind, name)
+	if i < 0 {
+		writeNotFound(w)
+		return
+	}
+
+	s.domains = append(s.domains[:i], s.domains[i+1:]...)
+
+	w.WriteHeader(http.StatusNoContent)
+}
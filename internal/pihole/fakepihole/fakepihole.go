@@ -0,0 +1,288 @@
+// Package fakepihole provides an in-memory stand-in for the Pi-hole v6 API, serving the endpoints used by the
+// provider over httptest so that client and provider tests run without a Pi-hole
+package fakepihole
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"time"
+)
+
+// DefaultPassword is the web interface password accepted when none is configured
+const DefaultPassword = "test"
+
+// DefaultSessionValidity is how long a session stays valid without requests, matching Pi-hole's default
+const DefaultSessionValidity = 30 * time.Minute
+
+type Config struct {
+	// Password accepted by /api/auth, DefaultPassword is used when not set
+	Password string
+	// SessionValidity is how long a session stays valid without requests, DefaultSessionValidity is used when not set
+	SessionValidity time.Duration
+}
+
+// Server is a Pi-hole v6 API stand-in holding its state in memory
+type Server struct {
+	*httptest.Server
+
+	password string
+	validity time.Duration
+
+	mu       sync.Mutex
+	logins   int
+	sessions map[string]*session
+
+	// config holds the config arrays served under /api/config, keyed by their dotted path
+	config map[string]*configArray
+
+	groups      []*group
+	nextGroupID int64
+
+	domains      []*domain
+	nextDomainID int64
+
+	blocking      bool
+	blockingUntil time.Time
+}
+
+type session struct {
+	csrf    string
+	expires time.Time
+}
+
+// New starts a fake Pi-hole, the caller must close it once done
+func New(config Config) *Server {
+	s := &Server{
+		password:     config.Password,
+		validity:     config.SessionValidity,
+		sessions:     map[string]*session{},
+		config:       newConfigArrays(),
+		nextGroupID:  1,
+		nextDomainID: 1,
+		blocking:     true,
+	}
+
+	if s.password == "" {
+		s.password = DefaultPassword
+	}
+
+	if s.validity <= 0 {
+		s.validity = DefaultSessionValidity
+	}
+
+	now := time.Now().Unix()
+	comment := "The default group"
+	s.groups = []*group{{
+		ID:           0,
+		Name:         "Default",
+		Comment:      &comment,
+		Enabled:      true,
+		DateAdded:    now,
+		DateModified: now,
+	}}
+
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("POST /api/auth", s.handleLogin)
+	mux.HandleFunc("DELETE /api/auth", s.handleLogout)
+
+	mux.HandleFunc("PATCH /api/config", s.authenticated(s.handlePatchConfig))
+
+	mux.HandleFunc("GET /api/config/{section}/{key}", s.authenticated(s.handleListConfigItems))
+	mux.HandleFunc("PUT /api/config/{section}/{key}/{entry}", s.authenticated(s.handleAddConfigItem))
+	mux.HandleFunc("DELETE /api/config/{section}/{key}/{entry}", s.authenticated(s.handleDeleteConfigItem))
+
+	mux.HandleFunc("GET /api/groups", s.authenticated(s.handleListGroups))
+	mux.HandleFunc("GET /api/groups/{name}", s.authenticated(s.handleListGroups))
+	mux.HandleFunc("POST /api/groups", s.authenticated(s.handleAddGroup))
+	mux.HandleFunc("PUT /api/groups/{name}", s.authenticated(s.handleUpdateGroup))
+	mux.HandleFunc("DELETE /api/groups/{name}", s.authenticated(s.handleDeleteGroup))
+
+	mux.HandleFunc("GET /api/dns/blocking", s.authenticated(s.handleGetBlocking))
+	mux.HandleFunc("POST /api/dns/blocking", s.authenticated(s.handleSetBlocking))
+
+	mux.HandleFunc("GET /api/domains", s.authenticated(s.handleListDomains))
+	mux.HandleFunc("GET /api/domains/{type}", s.authenticated(s.handleListDomains))
+	mux.HandleFunc("GET /api/domains/{type}/{kind}", s.authenticated(s.handleListDomains))
+	mux.HandleFunc("GET /api/domains/{type}/{kind}/{domain}", s.authenticated(s.handleListDomains))
+	mux.HandleFunc("POST /api/domains/{type}/{kind}", s.authenticated(s.handleAddDomain))
+	mux.HandleFunc("PUT /api/domains/{type}/{kind}/{domain}", s.authenticated(s.handleUpdateDomain))
+	mux.HandleFunc("DELETE /api/domains/{type}/{kind}/{domain}", s.authenticated(s.handleDeleteDomain))
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		writeError(w, http.StatusNotFound, "not_found", "Not found", r.URL.Path)
+	})
+
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// Logins returns the number of successful logins
+func (s *Server) Logins() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.logins
+}
+
+// Sessions returns the number of active sessions
+func (s *Server) Sessions() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expireSessions(time.Now())
+
+	return len(s.sessions)
+}
+
+// ExpireSessions drops all sessions, as Pi-hole does when it restarts
+func (s *Server) ExpireSessions() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions = map[string]*session{}
+}
+
+// expireSessions drops the sessions which expired at the passed time, the caller must hold the lock
+func (s *Server) expireSessions(now time.Time) {
+	for sid, sess := range s.sessions {
+		if now.After(sess.expires) {
+			delete(s.sessions, sid)
+		}
+	}
+}
+
+// handleLogin creates a session when the password is correct
+func (s *Server) handleLogin(w http.ResponseWriter, r *http.Request) {
+	var body struct {
+		Password *string `json:"password"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid JSON payload", err.Error())
+		return
+	}
+
+	if body.Password == nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "No password found in JSON payload", nil)
+		return
+	}
+
+	if *body.Password != s.password {
+		writeJSON(w, http.StatusUnauthorized, map[string]any{
+			"session": map[string]any{
+				"valid":    false,
+				"totp":     false,
+				"sid":      nil,
+				"validity": -1,
+				"message":  "password incorrect",
+			},
+		})
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sid := randomToken()
+	sess := &session{
+		csrf:    randomToken(),
+		expires: time.Now().Add(s.validity),
+	}
+	s.sessions[sid] = sess
+	s.logins++
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"session": map[string]any{
+			"valid":    true,
+			"totp":     false,
+			"sid":      sid,
+			"csrf":     sess.csrf,
+			"validity": int(s.validity.Seconds()),
+			"message":  "password correct",
+		},
+	})
+}
+
+// handleLogout deletes the session of the request
+func (s *Server) handleLogout(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.expireSessions(time.Now())
+
+	sid := r.Header.Get("X-FTL-SID")
+	if _, ok := s.sessions[sid]; !ok {
+		writeUnauthorized(w)
+		return
+	}
+
+	delete(s.sessions, sid)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authenticated rejects requests without a valid session and extends the session of the others
+func (s *Server) authenticated(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		now := time.Now()
+		s.expireSessions(now)
+
+		sess, ok := s.sessions[r.Header.Get("X-FTL-SID")]
+		if ok {
+			sess.expires = now.Add(s.validity)
+		}
+		s.mu.Unlock()
+
+		if !ok {
+			writeUnauthorized(w)
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// randomToken returns a random session token
+func randomToken() string {
+	b := make([]byte, 18)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+
+	return base64.StdEncoding.EncodeToString(b)
+}
+
+// writeJSON writes a JSON response with the processing time Pi-hole adds to every response
+func writeJSON(w http.ResponseWriter, statusCode int, body map[string]any) {
+	body["took"] = 0.0001
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(statusCode)
+	json.NewEncoder(w).Encode(body) //nolint:errcheck
+}
+
+// writeError writes an error response in the format of the Pi-hole v6 API
+func writeError(w http.ResponseWriter, statusCode int, key string, message string, hint any) {
+	writeJSON(w, statusCode, map[string]any{
+		"error": map[string]any{
+			"key":     key,
+			"message": message,
+			"hint":    hint,
+		},
+	})
+}
+
+// writeUnauthorized writes the response Pi-hole returns to requests without a valid session
+func writeUnauthorized(w http.ResponseWriter) {
+	writeError(w, http.StatusUnauthorized, "unauthorized", "Unauthorized", nil)
+}
+
+// writeNotFound writes the response Pi-hole returns when the item to modify does not exist
+func writeNotFound(w http.ResponseWriter) {
+	writeError(w, http.StatusNotFound, "not_found", "Item not found", nil)
+}
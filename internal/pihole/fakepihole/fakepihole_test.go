@@ -0,0 +1,418 @@
+package fakepihole_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole/fakepihole"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newClient returns a logged in client of a new fake Pi-hole
+func newClient(t *testing.T) (*pihole.Client, *fakepihole.Server) {
+	server := fakepihole.New(fakepihole.Config{})
+	t.Cleanup(server.Close)
+
+	client := pihole.New(pihole.Config{
+		URL:      server.URL,
+		Password: fakepihole.DefaultPassword,
+	})
+	require.NoError(t, client.Login(context.Background()))
+
+	return client, server
+}
+
+// requireAPIError asserts that err carries an APIError with the passed status code and key
+func requireAPIError(t *testing.T, err error, statusCode int, key string) *pihole.APIError {
+	var apiErr *pihole.APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, statusCode, apiErr.StatusCode)
+	require.Equal(t, key, apiErr.Key)
+
+	return apiErr
+}
+
+func TestAuth(t *testing.T) {
+	t.Run("Reject requests without a session", func(t *testing.T) {
+		t.Parallel()
+
+		server := fakepihole.New(fakepihole.Config{})
+		defer server.Close()
+
+		res, err := http.Get(server.URL + "/api/groups")
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+	})
+
+	t.Run("Log in again once the session expired", func(t *testing.T) {
+		t.Parallel()
+
+		client, server := newClient(t)
+
+		server.ExpireSessions()
+
+		_, err := client.ListGroups(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, 2, server.Logins())
+	})
+
+	t.Run("Log out", func(t *testing.T) {
+		t.Parallel()
+
+		client, server := newClient(t)
+		require.Equal(t, 1, server.Sessions())
+
+		require.NoError(t, client.Logout(context.Background()))
+		require.Equal(t, 0, server.Sessions())
+	})
+}
+
+func TestDNSRecords(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, server := newClient(t)
+
+	_, err := client.CreateDNSRecord(ctx, &pihole.DNSRecord{Domain: "foo.lan", IP: "127.0.0.1"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"127.0.0.1 foo.lan"}, server.Hosts())
+
+	record, err := client.GetDNSRecord(ctx, "foo.lan")
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1", record.IP)
+
+	_, err = client.CreateDNSRecord(ctx, &pihole.DNSRecord{Domain: "foo.lan", IP: "127.0.0.1"})
+	apiErr := requireAPIError(t, err, http.StatusBadRequest, "bad_request")
+	require.Equal(t, "Item already present", apiErr.Message)
+
+	_, err = client.CreateDNSRecord(ctx, &pihole.DNSRecord{Domain: "bar.lan", IP: "not-an-ip"})
+	requireAPIError(t, err, http.StatusBadRequest, "bad_request")
+
+	require.NoError(t, client.DeleteDNSRecord(ctx, "foo.lan"))
+	require.Empty(t, server.Hosts())
+
+	_, err = client.GetDNSRecord(ctx, "foo.lan")
+	require.ErrorAs(t, err, new(*pihole.NotFoundError))
+
+	t.Run("Entries with multiple hostnames", func(t *testing.T) {
+		server.SetHosts([]string{"192.168.1.10\tnas  nas.lan # storage", "127.0.0.1 localhost"})
+
+		records, err := client.ListDNSRecords(ctx)
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+
+		record, err := client.GetDNSRecord(ctx, "nas.lan")
+		require.NoError(t, err)
+		require.Equal(t, "192.168.1.10", record.IP)
+
+		require.NoError(t, client.DeleteDNSRecord(ctx, "nas"))
+		require.Equal(t, []string{"127.0.0.1 localhost", "192.168.1.10  nas.lan # storage"}, server.Hosts())
+
+		require.NoError(t, client.DeleteDNSRecord(ctx, "nas.lan"))
+		require.Equal(t, []string{"127.0.0.1 localhost"}, server.Hosts())
+	})
+
+	t.Run("Update a record in place", func(t *testing.T) {
+		server.SetHosts([]string{"192.168.1.10 nas nas.lan # storage", "127.0.0.1\tlocalhost # loopback"})
+
+		_, err := client.UpdateDNSRecord(ctx, &pihole.DNSRecord{Domain: "nas.lan", IP: "192.168.1.20"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"192.168.1.10 nas # storage", "192.168.1.20 nas.lan", "127.0.0.1\tlocalhost # loopback"}, server.Hosts())
+
+		_, err = client.UpdateDNSRecord(ctx, &pihole.DNSRecord{Domain: "localhost", IP: "127.0.0.2"})
+		require.NoError(t, err)
+		require.Equal(t, []string{"192.168.1.10 nas # storage", "192.168.1.20 nas.lan", "127.0.0.2\tlocalhost # loopback"}, server.Hosts())
+
+		_, err = client.UpdateDNSRecord(ctx, &pihole.DNSRecord{Domain: "missing.lan", IP: "192.168.1.20"})
+		require.ErrorAs(t, err, new(*pihole.NotFoundError))
+
+		_, err = client.UpdateDNSRecord(ctx, &pihole.DNSRecord{Domain: "nas", IP: "not-an-ip"})
+		requireAPIError(t, err, http.StatusBadRequest, "bad_request")
+	})
+
+	t.Run("Ignore malformed entries", func(t *testing.T) {
+		server.SetHosts([]string{"not-an-ip foo.lan", "127.0.0.1", "127.0.0.1 localhost"})
+
+		records, err := client.ListDNSRecords(ctx)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, "localhost", records[0].Domain)
+	})
+}
+
+func TestCNAMERecords(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, server := newClient(t)
+
+	_, err := client.CreateCNAMERecord(ctx, &pihole.CNAMERecord{Domain: "foo.lan", Target: "bar.lan"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"foo.lan,bar.lan"}, server.CNAMERecords())
+
+	record, err := client.GetCNAMERecord(ctx, "foo.lan")
+	require.NoError(t, err)
+	require.Equal(t, "bar.lan", record.Target)
+
+	_, err = client.CreateCNAMERecord(ctx, &pihole.CNAMERecord{Domain: "foo.lan", Target: "bar.lan"})
+	requireAPIError(t, err, http.StatusBadRequest, "bad_request")
+
+	require.NoError(t, client.DeleteCNAMERecord(ctx, "foo.lan"))
+	require.Empty(t, server.CNAMERecords())
+
+	t.Run("Entries with a TTL", func(t *testing.T) {
+		_, err := client.CreateCNAMERecord(ctx, &pihole.CNAMERecord{Domain: "foo.lan", Target: "bar.lan", TTL: 300})
+		require.NoError(t, err)
+		require.Equal(t, []string{"foo.lan,bar.lan,300"}, server.CNAMERecords())
+
+		record, err := client.GetCNAMERecord(ctx, "foo.lan")
+		require.NoError(t, err)
+		require.Equal(t, 300, record.TTL)
+
+		require.NoError(t, client.DeleteCNAMERecord(ctx, "foo.lan"))
+		require.Empty(t, server.CNAMERecords())
+	})
+
+	t.Run("Entries with multiple domains", func(t *testing.T) {
+		server.SetCNAMERecords([]string{"a.lan,b.lan,target.lan,60", "foo.lan,bar.lan"})
+
+		records, err := client.ListCNAMERecords(ctx)
+		require.NoError(t, err)
+		require.Len(t, records, 3)
+
+		record, err := client.GetCNAMERecord(ctx, "b.lan")
+		require.NoError(t, err)
+		require.Equal(t, "target.lan", record.Target)
+		require.Equal(t, 60, record.TTL)
+
+		err = client.DeleteCNAMERecord(ctx, "a.lan")
+		require.ErrorContains(t, err, "shares the dns.cnameRecords entry")
+
+		_, err = client.UpdateCNAMERecord(ctx, &pihole.CNAMERecord{Domain: "a.lan", Target: "other.lan"})
+		require.ErrorContains(t, err, "shares the dns.cnameRecords entry")
+
+		require.Equal(t, []string{"a.lan,b.lan,target.lan,60", "foo.lan,bar.lan"}, server.CNAMERecords())
+	})
+
+	t.Run("Ignore malformed entries", func(t *testing.T) {
+		server.SetCNAMERecords([]string{"a.lan,,target.lan", "foo.lan,bar.lan"})
+
+		records, err := client.ListCNAMERecords(ctx)
+		require.NoError(t, err)
+		require.Len(t, records, 1)
+		require.Equal(t, "foo.lan", records[0].Domain)
+
+		_, err = client.GetCNAMERecord(ctx, "a.lan")
+		require.ErrorAs(t, err, new(*pihole.NotFoundError))
+	})
+
+	t.Run("Update a record in place", func(t *testing.T) {
+		server.SetCNAMERecords([]string{"foo.lan,bar.lan", "b.lan,target.lan,60"})
+
+		_, err := client.UpdateCNAMERecord(ctx, &pihole.CNAMERecord{Domain: "foo.lan", Target: "baz.lan", TTL: 120})
+		require.NoError(t, err)
+		require.Equal(t, []string{"foo.lan,baz.lan,120", "b.lan,target.lan,60"}, server.CNAMERecords())
+
+		_, err = client.UpdateCNAMERecord(ctx, &pihole.CNAMERecord{Domain: "missing.lan", Target: "baz.lan"})
+		require.ErrorAs(t, err, new(*pihole.NotFoundError))
+	})
+}
+
+func TestConcurrentDNSChanges(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, server := newClient(t)
+
+	server.SetHosts([]string{"10.0.0.1 a.lan", "10.0.0.2 b.lan", "10.0.0.3 c.lan"})
+
+	// Updates replace the whole array, concurrent changes of the same client must not overwrite each other
+	var wg sync.WaitGroup
+	for _, domain := range []string{"a.lan", "b.lan", "c.lan"} {
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+
+			_, err := client.UpdateDNSRecord(ctx, &pihole.DNSRecord{Domain: domain, IP: "10.0.1.1"})
+			assert.NoError(t, err)
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			_, err := client.CreateDNSRecord(ctx, &pihole.DNSRecord{Domain: "new." + domain, IP: "10.0.2.1"})
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	records, err := client.ListDNSRecords(ctx)
+	require.NoError(t, err)
+	require.Len(t, records, 6)
+
+	for _, record := range records {
+		if strings.HasPrefix(record.Domain, "new.") {
+			require.Equal(t, "10.0.2.1", record.IP)
+		} else {
+			require.Equal(t, "10.0.1.1", record.IP)
+		}
+	}
+}
+
+func TestConfigArrays(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, _ := newClient(t)
+
+	upstreams, err := client.SetUpstreams(ctx, []string{"9.9.9.9", "127.0.0.1#5335"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"9.9.9.9", "127.0.0.1#5335"}, upstreams)
+
+	_, err = client.CreateConditionalForwarder(ctx, &pihole.ConditionalForwarder{Active: true, CIDR: "192.168.10.0/24", Server: "192.168.10.1", Domain: "lab"})
+	require.NoError(t, err)
+
+	forwarder, err := client.GetConditionalForwarder(ctx, "192.168.10.0/24")
+	require.NoError(t, err)
+	require.Equal(t, "192.168.10.1", forwarder.Server)
+
+	require.NoError(t, client.DeleteConditionalForwarder(ctx, "192.168.10.0/24"))
+
+	_, err = client.CreateDHCPStaticLease(ctx, &pihole.DHCPStaticLease{MAC: "AA:BB:CC:DD:EE:FF", IP: "192.168.1.20", Hostname: "printer"})
+	require.NoError(t, err)
+
+	lease, err := client.GetDHCPStaticLease(ctx, "aa:bb:cc:dd:ee:ff")
+	require.NoError(t, err)
+	require.Equal(t, "printer", lease.Hostname)
+
+	require.NoError(t, client.DeleteDHCPStaticLease(ctx, "AA:BB:CC:DD:EE:FF"))
+
+	leases, err := client.ListDHCPStaticLeases(ctx)
+	require.NoError(t, err)
+	require.Empty(t, leases)
+}
+
+func TestGroups(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, _ := newClient(t)
+
+	group, err := client.CreateGroup(ctx, &pihole.GroupCreateRequest{Name: "kids", Description: "Kids devices"})
+	require.NoError(t, err)
+	require.Equal(t, int64(1), group.ID)
+	require.True(t, group.Enabled)
+	require.Equal(t, "Kids devices", group.Description)
+
+	_, err = client.CreateGroup(ctx, &pihole.GroupCreateRequest{Name: "kids"})
+	require.ErrorContains(t, err, "UNIQUE constraint failed")
+
+	group, err = client.UpdateGroup(ctx, &pihole.GroupUpdateRequest{Name: "kids", Enabled: pihole.Bool(false)})
+	require.NoError(t, err)
+	require.False(t, group.Enabled)
+	require.Empty(t, group.Description)
+
+	groups, err := client.ListGroups(ctx)
+	require.NoError(t, err)
+	require.Len(t, groups, 2)
+
+	require.NoError(t, client.DeleteGroup(ctx, "kids"))
+
+	err = client.DeleteGroup(ctx, "kids")
+	requireAPIError(t, err, http.StatusNotFound, "not_found")
+
+	err = client.DeleteGroup(ctx, "Default")
+	requireAPIError(t, err, http.StatusBadRequest, "bad_request")
+}
+
+func TestBlocking(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, _ := newClient(t)
+
+	status, err := client.GetAdBlockerStatus(ctx)
+	require.NoError(t, err)
+	require.True(t, status.Enabled)
+
+	status, err = client.SetAdBlockEnabled(ctx, false, time.Hour)
+	require.NoError(t, err)
+	require.False(t, status.Enabled)
+	require.InDelta(t, float64(time.Hour), float64(status.Timer), float64(time.Minute))
+
+	status, err = client.SetAdBlockEnabled(ctx, false, 50*time.Millisecond)
+	require.NoError(t, err)
+	require.False(t, status.Enabled)
+
+	time.Sleep(100 * time.Millisecond)
+
+	status, err = client.GetAdBlockerStatus(ctx)
+	require.NoError(t, err)
+	require.True(t, status.Enabled)
+	require.Zero(t, status.Timer)
+}
+
+func TestDomains(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	client, _ := newClient(t)
+
+	domain, err := client.CreateDomain(ctx, &pihole.DomainCreateRequest{Domain: "ads.example.com", Type: pihole.DomainOptionsDeny})
+	require.NoError(t, err)
+	require.Equal(t, []int64{0}, domain.GroupIDs)
+	require.True(t, domain.Enabled)
+
+	_, err = client.CreateDomain(ctx, &pihole.DomainCreateRequest{Domain: "ads.example.com", Type: pihole.DomainOptionsDeny})
+	require.ErrorContains(t, err, "UNIQUE constraint failed")
+
+	_, err = client.CreateDomain(ctx, &pihole.DomainCreateRequest{Domain: "(ads", Type: pihole.DomainOptionsDeny, Wildcard: true})
+	require.ErrorContains(t, err, "Invalid regex")
+
+	_, err = client.CreateDomain(ctx, &pihole.DomainCreateRequest{Domain: `(^|\.)ads\.example\.com$`, Type: pihole.DomainOptionsAllow, Wildcard: true})
+	require.NoError(t, err)
+
+	domain, err = client.UpdateDomain(ctx, &pihole.DomainUpdateRequest{Domain: "ads.example.com", Type: pihole.DomainOptionsDeny, Comment: "ads", Enabled: pihole.Bool(false)})
+	require.NoError(t, err)
+	require.Equal(t, "ads", domain.Comment)
+	require.False(t, domain.Enabled)
+
+	domain, err = client.UpdateDomain(ctx, &pihole.DomainUpdateRequest{Domain: "ads.example.com", Type: pihole.DomainOptionsDeny, Comment: "ads", Enabled: pihole.Bool(false), GroupIDs: []int64{}})
+	require.NoError(t, err)
+	require.Empty(t, domain.GroupIDs)
+
+	domains, err := client.ListDomains(ctx, pihole.ListDomainsOptions{Type: pihole.DomainOptionsDeny})
+	require.NoError(t, err)
+	require.Len(t, domains, 1)
+
+	domains, err = client.ListDomains(ctx, pihole.ListDomainsOptions{Kind: pihole.DomainKindRegex})
+	require.NoError(t, err)
+	require.Len(t, domains, 1)
+
+	require.NoError(t, client.DeleteDomain(ctx, pihole.DomainOptionsDeny, false, "ads.example.com"))
+
+	err = client.DeleteDomain(ctx, pihole.DomainOptionsDeny, false, "ads.example.com")
+	requireAPIError(t, err, http.StatusNotFound, "not_found")
+}
+
+func TestNotFound(t *testing.T) {
+	t.Parallel()
+
+	server := fakepihole.New(fakepihole.Config{})
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/api/unknown")
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusNotFound, res.StatusCode)
+}
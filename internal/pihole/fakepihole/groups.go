@@ -0,0 +1,180 @@
+package fakepihole
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+type group struct {
+	ID           int64   `json:"id"`
+	Name         string  `json:"name"`
+	Comment      *string `json:"comment"`
+	Enabled      bool    `json:"enabled"`
+	DateAdded    int64   `json:"date_added"`
+	DateModified int64   `json:"date_modified"`
+}
+
+// groupRequest is the body of POST /api/groups and PUT /api/groups/<name>
+type groupRequest struct {
+	Name    *string `json:"name"`
+	Comment *string `json:"comment"`
+	Enabled *bool   `json:"enabled"`
+}
+
+// findGroup returns the index of the group with the passed name, the caller must hold the lock
+func (s *Server) findGroup(name string) int {
+	for i, g := range s.groups {
+		if g.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func (s *Server) handleListGroups(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	name := r.PathValue("name")
+
+	groups := []*group{}
+	for _, g := range s.groups {
+		if name == "" || g.Name == name {
+			groups = append(groups, g)
+		}
+	}
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"groups": groups,
+	})
+}
+
+func (s *Server) handleAddGroup(w http.ResponseWriter, r *http.Request) {
+	var body groupRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid JSON payload", err.Error())
+		return
+	}
+
+	if body.Name == nil || *body.Name == "" {
+		writeError(w, http.StatusBadRequest, "bad_request", "No \"name\" string in body data", nil)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.findGroup(*body.Name) >= 0 {
+		writeJSON(w, http.StatusCreated, map[string]any{
+			"groups":    []*group{},
+			"processed": processed(nil, *body.Name, "UNIQUE constraint failed: group.name"),
+		})
+		return
+	}
+
+	now := time.Now().Unix()
+	g := &group{
+		ID:           s.nextGroupID,
+		Name:         *body.Name,
+		Comment:      body.Comment,
+		Enabled:      body.Enabled == nil || *body.Enabled,
+		DateAdded:    now,
+		DateModified: now,
+	}
+	s.nextGroupID++
+	s.groups = append(s.groups, g)
+
+	writeJSON(w, http.StatusCreated, map[string]any{
+		"groups":    []*group{g},
+		"processed": processed([]string{g.Name}, "", ""),
+	})
+}
+
+func (s *Server) handleUpdateGroup(w http.ResponseWriter, r *http.Request) {
+	var body groupRequest
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+		writeError(w, http.StatusBadRequest, "bad_request", "Invalid JSON payload", err.Error())
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.findGroup(r.PathValue("name"))
+	if i < 0 {
+		writeNotFound(w)
+		return
+	}
+
+	g := s.groups[i]
+	if body.Name != nil && *body.Name != g.Name {
+		if *body.Name == "" || s.findGroup(*body.Name) >= 0 {
+			writeError(w, http.StatusBadRequest, "database_error", "Could not replace item in gravity database", "UNIQUE constraint failed: group.name")
+			return
+		}
+
+		g.Name = *body.Name
+	}
+
+	g.Comment = body.Comment
+	if body.Enabled != nil {
+		g.Enabled = *body.Enabled
+	}
+	g.DateModified = time.Now().Unix()
+
+	writeJSON(w, http.StatusOK, map[string]any{
+		"groups":    []*group{g},
+		"processed": processed([]string{g.Name}, "", ""),
+	})
+}
+
+func (s *Server) handleDeleteGroup(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	i := s.findGroup(r.PathValue("name"))
+	if i < 0 {
+		writeNotFound(w)
+		return
+	}
+
+	if s.groups[i].ID == 0 {
+		writeError(w, http.StatusBadRequest, "bad_request", "Cannot delete the default group", nil)
+		return
+	}
+
+	id := s.groups[i].ID
+	s.groups = append(s.groups[:i], s.groups[i+1:]...)
+
+	// Pi-hole removes the group from the domains it was assigned to
+	for _, d := range s.domains {
+		for j, gid := range d.Groups {
+			if gid == id {
+				d.Groups = append(d.Groups[:j], d.Groups[j+1:]...)
+				break
+			}
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// processed returns the processed object of list write responses, listing the successful items and the failed one
+func processed(success []string, failedItem string, failure string) map[string]any {
+	items := []map[string]string{}
+	for _, item := range success {
+		items = append(items, map[string]string{"item": item})
+	}
+
+	errors := []map[string]string{}
+	if failedItem != "" {
+		errors = append(errors, map[string]string{"item": failedItem, "error": failure})
+	}
+
+	return map[string]any{
+		"success": items,
+		"errors":  errors,
+	}
+}
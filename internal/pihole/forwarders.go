@@ -0,0 +1,167 @@
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/netip"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+type ConditionalForwarder struct {
+	Active bool
+	CIDR   string
+	Server string
+	Domain string
+}
+
+type ConditionalForwarderList []ConditionalForwarder
+
+// Entry returns the dns.revServers entry representing the forwarder, in the form active,cidr,server#port,domain
+func (f ConditionalForwarder) Entry() string {
+	return strings.Join([]string{strconv.FormatBool(f.Active), f.CIDR, f.Server, f.Domain}, ",")
+}
+
+// NormalizeCIDR returns the canonical form of a CIDR with the host bits masked
+func NormalizeCIDR(cidr string) (string, error) {
+	prefix, err := netip.ParsePrefix(strings.TrimSpace(cidr))
+	if err != nil {
+		return "", err
+	}
+
+	return prefix.Masked().String(), nil
+}
+
+// parseConditionalForwarder parses a dns.revServers entry
+func parseConditionalForwarder(entry string) (*ConditionalForwarder, error) {
+	splitted := strings.Split(entry, ",")
+	if len(splitted) != 4 {
+		return nil, fmt.Errorf("failed to parse conditional forwarder %q", entry)
+	}
+
+	active, err := strconv.ParseBool(splitted[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse conditional forwarder %q: %s", entry, err)
+	}
+
+	return &ConditionalForwarder{
+		Active: active,
+		CIDR:   splitted[1],
+		Server: splitted[2],
+		Domain: splitted[3],
+	}, nil
+}
+
+// ListConditionalForwarders returns the list of conditional forwarders configured in pihole
+func (c Client) ListConditionalForwarders(ctx context.Context) (ConditionalForwarderList, error) {
+	req, err := c.RequestWithSession2(ctx, "GET", "/api/config/dns/revServers", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to retrieve conditional forwarders: %w", newAPIError(res))
+	}
+
+	defer res.Body.Close()
+	type Response struct {
+		Config struct {
+			DNS struct {
+				RevServers []string `json:"revServers"`
+			} `json:"dns"`
+		} `json:"config"`
+	}
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	var response Response
+	if err := json.Unmarshal(b, &response); err != nil {
+		return nil, err
+	}
+
+	var list ConditionalForwarderList
+	for _, v := range response.Config.DNS.RevServers {
+		// A hand-edited entry that is not a valid conditional forwarder must not break reading the other ones
+		forwarder, err := parseConditionalForwarder(v)
+		if err != nil {
+			tflog.Warn(ctx, "Skipping malformed dns.revServers entry", map[string]interface{}{"entry": v, "error": err.Error()})
+			continue
+		}
+
+		list = append(list, *forwarder)
+	}
+
+	return list, nil
+}
+
+// GetConditionalForwarder returns the conditional forwarder for the passed CIDR if found
+func (c Client) GetConditionalForwarder(ctx context.Context, cidr string) (*ConditionalForwarder, error) {
+	normalized, err := NormalizeCIDR(cidr)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := c.ListConditionalForwarders(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, f := range list {
+		if n, err := NormalizeCIDR(f.CIDR); err == nil && n == normalized {
+			return &f, nil
+		}
+	}
+
+	return nil, NewNotFoundError(fmt.Sprintf("conditional forwarder for %q not found", cidr))
+}
+
+// CreateConditionalForwarder creates a conditional forwarder
+func (c Client) CreateConditionalForwarder(ctx context.Context, forwarder *ConditionalForwarder) (*ConditionalForwarder, error) {
+	req, err := c.RequestWithSession2(ctx, "PUT", fmt.Sprintf("/api/config/dns/revServers/%s", url.PathEscape(forwarder.Entry())), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != 201 {
+		return nil, fmt.Errorf("failed to create conditional forwarder: %w", newAPIError(res))
+	}
+
+	return forwarder, nil
+}
+
+// DeleteConditionalForwarder deletes the conditional forwarder for the passed CIDR
+func (c Client) DeleteConditionalForwarder(ctx context.Context, cidr string) error {
+	forwarder, err := c.GetConditionalForwarder(ctx, cidr)
+	if err != nil {
+		return err
+	}
+
+	req, err := c.RequestWithSession2(ctx, "DELETE", fmt.Sprintf("/api/config/dns/revServers/%s", url.PathEscape(forwarder.Entry())), nil)
+	if err != nil {
+		return err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	if res.StatusCode != 204 {
+		return fmt.Errorf("failed to delete conditional forwarder: %w", newAPIError(res))
+	}
+
+	return nil
+}
@@ -0,0 +1,77 @@
+package pihole
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseConditionalForwarder(t *testing.T) {
+	t.Run("Parse dns.revServers entries", func(t *testing.T) {
+		t.Parallel()
+
+		cases := map[string]ConditionalForwarder{
+			"true,192.168.0.0/16,192.168.0.1,lan": {
+				Active: true,
+				CIDR:   "192.168.0.0/16",
+				Server: "192.168.0.1",
+				Domain: "lan",
+			},
+			"false,10.0.0.0/8,10.0.0.53#5353,corp.example.com": {
+				Active: false,
+				CIDR:   "10.0.0.0/8",
+				Server: "10.0.0.53#5353",
+				Domain: "corp.example.com",
+			},
+			"true,fd00::/8,fd00::1,": {
+				Active: true,
+				CIDR:   "fd00::/8",
+				Server: "fd00::1",
+			},
+		}
+
+		for entry, expected := range cases {
+			forwarder, err := parseConditionalForwarder(entry)
+			require.NoError(t, err, entry)
+			require.Equal(t, expected, *forwarder, entry)
+			require.Equal(t, entry, forwarder.Entry(), entry)
+		}
+	})
+
+	t.Run("Fail to parse malformed entries", func(t *testing.T) {
+		t.Parallel()
+
+		for _, entry := range []string{"", "192.168.0.0/16,192.168.0.1,lan", "yes,192.168.0.0/16,192.168.0.1,lan"} {
+			_, err := parseConditionalForwarder(entry)
+			require.Error(t, err, entry)
+		}
+	})
+}
+
+func TestListConditionalForwarders(t *testing.T) {
+	t.Run("Skip malformed entries", func(t *testing.T) {
+		t.Parallel()
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"session":{"valid":true,"sid":"sid","csrf":"csrf","validity":300}}`)) //nolint:errcheck
+		})
+		mux.HandleFunc("/api/config/dns/revServers", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"config":{"dns":{"revServers":["yes,10.0.0.0/8,10.0.0.1,corp","true,192.168.0.0/16,192.168.0.1,lan"]}}}`)) //nolint:errcheck
+		})
+
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		client := New(Config{Password: "test", URL: server.URL})
+
+		list, err := client.ListConditionalForwarders(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, ConditionalForwarderList{
+			{Active: true, CIDR: "192.168.0.0/16", Server: "192.168.0.1", Domain: "lan"},
+		}, list)
+	})
+}
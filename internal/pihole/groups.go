@@ -70,10 +70,6 @@ func (gr GroupResponse) ToGroup() *Group {
 
 // ListGroups returns the list of gravity DB groups
 func (c Client) ListGroups(ctx context.Context) (GroupList, error) {
-	if c.tokenClient != nil {
-		return nil, fmt.Errorf("%w: list groups", ErrNotImplementedTokenClient)
-	}
-
 	req, err := c.RequestWithSession2(ctx, "GET", "/api/groups", nil)
 	if err != nil {
 		return nil, err
@@ -84,7 +80,7 @@ func (c Client) ListGroups(ctx context.Context) (GroupList, error) {
 		return nil, err
 	}
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to retrieve groups, got status code %d", res.StatusCode)
+		return nil, fmt.Errorf("failed to retrieve groups: %w", newAPIError(res))
 	}
 
 	defer res.Body.Close()
@@ -129,10 +125,6 @@ func (c Client) ListGroups(ctx context.Context) (GroupList, error) {
 
 // GetGroup returns a Pi-hole group by name
 func (c Client) GetGroup(ctx context.Context, name string) (*Group, error) {
-	if c.tokenClient != nil {
-		return nil, fmt.Errorf("%w: get groups", ErrNotImplementedTokenClient)
-	}
-
 	groups, err := c.ListGroups(ctx)
 	if err != nil {
 		return nil, err
@@ -148,10 +140,6 @@ func (c Client) GetGroup(ctx context.Context, name string) (*Group, error) {
 
 // GetGroupByID returns a Pi-hole group by ID
 func (c Client) GetGroupByID(ctx context.Context, id int64) (*Group, error) {
-	if c.tokenClient != nil {
-		return nil, fmt.Errorf("%w: get group", ErrNotImplementedTokenClient)
-	}
-
 	groups, err := c.ListGroups(ctx)
 	if err != nil {
 		return nil, err
@@ -180,10 +168,6 @@ type GroupBasicResponse struct {
 
 // CreateGroup creates a group with the passed attributes
 func (c Client) CreateGroup(ctx context.Context, gr *GroupCreateRequest) (*Group, error) {
-	if c.tokenClient != nil {
-		return nil, fmt.Errorf("%w: create group", ErrNotImplementedTokenClient)
-	}
-
 	name := strings.TrimSpace(gr.Name)
 
 	if !validGroupName(name) {
@@ -203,7 +187,29 @@ func (c Client) CreateGroup(ctx context.Context, gr *GroupCreateRequest) (*Group
 		return nil, err
 	}
 	if res.StatusCode != 201 {
-		return nil, fmt.Errorf("failed to create group, got status code %d", res.StatusCode)
+		return nil, fmt.Errorf("failed to create group: %w", newAPIError(res))
+	}
+
+	defer res.Body.Close()
+	type Response struct {
+		Processed *struct {
+			Errors []struct {
+				Item    string `json:"item"`
+				Message string `json:"error"`
+			} `json:"errors"`
+		} `json:"processed"`
+	}
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	var response Response
+	if err := json.Unmarshal(b, &response); err != nil {
+		return nil, err
+	}
+
+	if response.Processed != nil && len(response.Processed.Errors) > 0 {
+		return nil, fmt.Errorf("failed to create group %q: %s", name, response.Processed.Errors[0].Message)
 	}
 
 	return c.GetGroup(ctx, name)
@@ -211,10 +217,6 @@ func (c Client) CreateGroup(ctx context.Context, gr *GroupCreateRequest) (*Group
 
 // UpdateGroup updates a group resource with the passed attribute
 func (c Client) UpdateGroup(ctx context.Context, gr *GroupUpdateRequest) (*Group, error) {
-	if c.tokenClient != nil {
-		return nil, fmt.Errorf("%w: update group", ErrNotImplementedTokenClient)
-	}
-
 	path := fmt.Sprintf("/api/groups/%s", gr.Name)
 	req, err := c.RequestWithSession2(ctx, "PUT", path, map[string]any{
 		"name":    gr.Name,
@@ -230,7 +232,7 @@ func (c Client) UpdateGroup(ctx context.Context, gr *GroupUpdateRequest) (*Group
 		return nil, err
 	}
 	if res.StatusCode != 200 {
-		return nil, fmt.Errorf("failed to update group, got status code %d", res.StatusCode)
+		return nil, fmt.Errorf("failed to update group: %w", newAPIError(res))
 	}
 
 	return c.GetGroup(ctx, gr.Name)
@@ -238,10 +240,6 @@ func (c Client) UpdateGroup(ctx context.Context, gr *GroupUpdateRequest) (*Group
 
 // DeleteGroup deletes a group
 func (c Client) DeleteGroup(ctx context.Context, name string) error {
-	if c.tokenClient != nil {
-		return fmt.Errorf("%w: delete group", ErrNotImplementedTokenClient)
-	}
-
 	path := fmt.Sprintf("/api/groups/%s", name)
 	req, err := c.RequestWithSession2(ctx, "DELETE", path, nil)
 	if err != nil {
@@ -253,7 +251,7 @@ func (c Client) DeleteGroup(ctx context.Context, name string) error {
 		return err
 	}
 	if res.StatusCode != 204 {
-		return fmt.Errorf("failed to delete group, got status code %d", res.StatusCode)
+		return fmt.Errorf("failed to delete group: %w", newAPIError(res))
 	}
 
 	return nil
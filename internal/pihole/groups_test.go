@@ -0,0 +1,53 @@
+package pihole
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateGroup(t *testing.T) {
+	newServer := func(t *testing.T, created string) *httptest.Server {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"session":{"valid":true,"sid":"sid","csrf":"csrf","validity":300}}`)) //nolint:errcheck
+		})
+		mux.HandleFunc("POST /api/groups", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(created)) //nolint:errcheck
+		})
+		mux.HandleFunc("GET /api/groups", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"groups":[{"id":1,"name":"kids","comment":"Kids devices","enabled":true}]}`)) //nolint:errcheck
+		})
+
+		server := httptest.NewServer(mux)
+		t.Cleanup(server.Close)
+
+		return server
+	}
+
+	t.Run("Return the created group", func(t *testing.T) {
+		t.Parallel()
+
+		server := newServer(t, `{"groups":[],"processed":{"errors":[],"success":[{"item":"kids"}]}}`)
+		client := New(Config{Password: "test", URL: server.URL})
+
+		group, err := client.CreateGroup(context.Background(), &GroupCreateRequest{Name: "kids", Description: "Kids devices"})
+		require.NoError(t, err)
+		require.Equal(t, "kids", group.Name)
+		require.Equal(t, "Kids devices", group.Description)
+	})
+
+	t.Run("Fail if Pi-hole did not process the group", func(t *testing.T) {
+		t.Parallel()
+
+		server := newServer(t, `{"groups":[],"processed":{"errors":[{"item":"kids","error":"UNIQUE constraint failed: group.name"}],"success":[]}}`)
+		client := New(Config{Password: "test", URL: server.URL})
+
+		_, err := client.CreateGroup(context.Background(), &GroupCreateRequest{Name: "kids"})
+		require.EqualError(t, err, `failed to create group "kids": UNIQUE constraint failed: group.name`)
+	})
+}
@@ -0,0 +1,131 @@
+package pihole
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRateLimiter(t *testing.T) {
+	t.Run("Allow a burst then space requests out", func(t *testing.T) {
+		t.Parallel()
+
+		limiter := newRateLimiter(2, 3)
+		now := time.Now()
+
+		require.Equal(t, time.Duration(0), limiter.reserve(now))
+		require.Equal(t, time.Duration(0), limiter.reserve(now))
+		require.Equal(t, time.Duration(0), limiter.reserve(now))
+		require.Equal(t, 500*time.Millisecond, limiter.reserve(now))
+		require.Equal(t, time.Second, limiter.reserve(now))
+	})
+
+	t.Run("Refill tokens over time up to the burst", func(t *testing.T) {
+		t.Parallel()
+
+		limiter := newRateLimiter(10, 2)
+		now := time.Now()
+
+		require.Equal(t, time.Duration(0), limiter.reserve(now))
+		require.Equal(t, time.Duration(0), limiter.reserve(now))
+
+		now = now.Add(time.Hour)
+		require.Equal(t, time.Duration(0), limiter.reserve(now))
+		require.Equal(t, time.Duration(0), limiter.reserve(now))
+		require.Equal(t, 100*time.Millisecond, limiter.reserve(now))
+	})
+
+	t.Run("Allow a single request without a burst", func(t *testing.T) {
+		t.Parallel()
+
+		limiter := newRateLimiter(1, 0)
+		now := time.Now()
+
+		require.Equal(t, time.Duration(0), limiter.reserve(now))
+		require.Equal(t, time.Second, limiter.reserve(now))
+	})
+
+	t.Run("Give back the token when the context is canceled", func(t *testing.T) {
+		t.Parallel()
+
+		limiter := newRateLimiter(0.1, 1)
+		require.NoError(t, limiter.Wait(context.Background()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		require.ErrorIs(t, limiter.Wait(ctx), context.DeadlineExceeded)
+		require.Equal(t, 10*time.Second, limiter.reserve(limiter.last).Round(time.Second))
+	})
+}
+
+func TestRateLimitTransport(t *testing.T) {
+	t.Run("Limit concurrent requests", func(t *testing.T) {
+		t.Parallel()
+
+		var calls int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&calls, 1)
+		}))
+		defer server.Close()
+
+		client := &http.Client{
+			Transport: &rateLimitTransport{
+				base:    http.DefaultTransport,
+				limiter: newRateLimiter(50, 2),
+			},
+		}
+
+		start := time.Now()
+
+		var wg sync.WaitGroup
+		for i := 0; i < 7; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+
+				res, err := client.Get(server.URL)
+				if assert.NoError(t, err) {
+					res.Body.Close()
+				}
+			}()
+		}
+		wg.Wait()
+
+		require.Equal(t, int32(7), atomic.LoadInt32(&calls))
+		require.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+	})
+
+	t.Run("Rate limit logins", func(t *testing.T) {
+		t.Parallel()
+
+		var logins int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&logins, 1)
+			w.WriteHeader(http.StatusUnauthorized)
+		}))
+		defer server.Close()
+
+		client := New(Config{
+			URL:               server.URL,
+			Password:          "test",
+			RequestsPerSecond: 0.1,
+			Burst:             1,
+		})
+
+		require.Error(t, client.Login(context.Background()))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+
+		require.ErrorContains(t, client.Login(ctx), context.DeadlineExceeded.Error())
+		require.Equal(t, int32(1), atomic.LoadInt32(&logins))
+	})
+}
@@ -0,0 +1,111 @@
+package pihole
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// retryBaseWait is the wait before the first retry, doubled on every following attempt
+	retryBaseWait = time.Second
+	// DefaultRetryMaxWait caps the wait between two attempts when no maximum is configured
+	DefaultRetryMaxWait = 30 * time.Second
+)
+
+// retryableStatusCodes are the responses of a Pi-hole, or of a proxy in front of it, which are worth retrying
+var retryableStatusCodes = map[int]bool{
+	http.StatusTooManyRequests:    true,
+	http.StatusBadGateway:         true,
+	http.StatusServiceUnavailable: true,
+	http.StatusGatewayTimeout:     true,
+}
+
+// retryTransport retries requests rejected with 429, 502, 503 or 504, backing off exponentially and honoring Retry-After
+type retryTransport struct {
+	base       http.RoundTripper
+	maxRetries int
+	maxWait    time.Duration
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	res, err := t.base.RoundTrip(req)
+
+	for attempt := 0; attempt < t.maxRetries; attempt++ {
+		if err != nil || !retryableStatusCodes[res.StatusCode] || !retryable(req, res.StatusCode) {
+			break
+		}
+
+		wait := retryWait(attempt, res.Header.Get("Retry-After"), t.maxWait)
+		res.Body.Close()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+
+		retry := req.Clone(req.Context())
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+
+			retry.Body = body
+		}
+
+		res, err = t.base.RoundTrip(retry)
+	}
+
+	return res, err
+}
+
+// retryable indicates whether the request can safely be sent again after the passed status code. Reads and deletes
+// are always retried. Writes, including PUT which adds config array entries and fails if Pi-hole already processed it
+// before a 502, 503 or 504, are only retried after 429 as Pi-hole did not process them.
+func retryable(req *http.Request, statusCode int) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodDelete:
+		return true
+	default:
+		return statusCode == http.StatusTooManyRequests
+	}
+}
+
+// retryWait returns how long to wait before the passed retry attempt (starting at 0), honoring the Retry-After header
+func retryWait(attempt int, retryAfter string, maxWait time.Duration) time.Duration {
+	if maxWait <= 0 {
+		maxWait = DefaultRetryMaxWait
+	}
+
+	wait := retryBaseWait << attempt
+	if wait <= 0 || wait > maxWait {
+		wait = maxWait
+	}
+
+	if retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+			wait = time.Duration(seconds) * time.Second
+		} else if date, err := http.ParseTime(retryAfter); err == nil {
+			wait = time.Until(date)
+		}
+	}
+
+	if wait < 0 {
+		wait = 0
+	}
+
+	if wait > maxWait {
+		wait = maxWait
+	}
+
+	return wait
+}
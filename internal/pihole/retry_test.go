@@ -0,0 +1,165 @@
+package pihole
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryWait(t *testing.T) {
+	t.Run("Back off exponentially up to the maximum wait", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, time.Second, retryWait(0, "", 30*time.Second))
+		require.Equal(t, 2*time.Second, retryWait(1, "", 30*time.Second))
+		require.Equal(t, 16*time.Second, retryWait(4, "", 30*time.Second))
+		require.Equal(t, 30*time.Second, retryWait(5, "", 30*time.Second))
+		require.Equal(t, 30*time.Second, retryWait(100, "", 30*time.Second))
+		require.Equal(t, DefaultRetryMaxWait, retryWait(100, "", 0))
+	})
+
+	t.Run("Honor Retry-After", func(t *testing.T) {
+		t.Parallel()
+
+		require.Equal(t, 7*time.Second, retryWait(0, "7", 30*time.Second))
+		require.Equal(t, time.Duration(0), retryWait(3, "0", 30*time.Second))
+		require.Equal(t, 30*time.Second, retryWait(0, "120", 30*time.Second))
+
+		date := retryWait(0, time.Now().Add(10*time.Second).UTC().Format(http.TimeFormat), 30*time.Second)
+		require.InDelta(t, float64(10*time.Second), float64(date), float64(2*time.Second))
+
+		require.Equal(t, time.Duration(0), retryWait(0, time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat), 30*time.Second))
+		require.Equal(t, time.Second, retryWait(0, "soon", 30*time.Second))
+	})
+}
+
+// newRetryTestClient returns an HTTP client retrying requests to a server answering with the passed status codes in order
+func newRetryTestClient(t *testing.T, maxRetries int, statusCodes ...int) (*http.Client, string, *int32) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		i := atomic.AddInt32(&calls, 1) - 1
+
+		b, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		if r.Method != http.MethodGet && r.Method != http.MethodDelete {
+			assert.Equal(t, "body", string(b))
+		}
+
+		statusCode := http.StatusOK
+		if int(i) < len(statusCodes) {
+			statusCode = statusCodes[i]
+		}
+
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(statusCode)
+	}))
+	t.Cleanup(server.Close)
+
+	return &http.Client{
+		Transport: &retryTransport{
+			base:       http.DefaultTransport,
+			maxRetries: maxRetries,
+			maxWait:    time.Second,
+		},
+	}, server.URL, &calls
+}
+
+func TestRetryTransport(t *testing.T) {
+	t.Run("Retry idempotent requests on retryable status codes", func(t *testing.T) {
+		t.Parallel()
+
+		client, url, calls := newRetryTestClient(t, 5, 429, 502, 503, 504)
+
+		req, err := http.NewRequest(http.MethodDelete, url, nil)
+		require.NoError(t, err)
+
+		res, err := client.Do(req)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		require.Equal(t, http.StatusOK, res.StatusCode)
+		require.Equal(t, int32(5), atomic.LoadInt32(calls))
+	})
+
+	t.Run("Give up after the maximum number of retries", func(t *testing.T) {
+		t.Parallel()
+
+		client, url, calls := newRetryTestClient(t, 2, 503, 503, 503, 503)
+
+		res, err := client.Get(url)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		require.Equal(t, http.StatusServiceUnavailable, res.StatusCode)
+		require.Equal(t, int32(3), atomic.LoadInt32(calls))
+	})
+
+	t.Run("Do not retry other status codes", func(t *testing.T) {
+		t.Parallel()
+
+		client, url, calls := newRetryTestClient(t, 5, 500)
+
+		res, err := client.Get(url)
+		require.NoError(t, err)
+		defer res.Body.Close()
+
+		require.Equal(t, http.StatusInternalServerError, res.StatusCode)
+		require.Equal(t, int32(1), atomic.LoadInt32(calls))
+	})
+
+	t.Run("Only retry non-idempotent requests on 429", func(t *testing.T) {
+		t.Parallel()
+
+		for _, method := range []string{http.MethodPost, http.MethodPut, http.MethodPatch} {
+			client, url, calls := newRetryTestClient(t, 5, 429, 503)
+
+			req, err := http.NewRequest(method, url, strings.NewReader("body"))
+			require.NoError(t, err)
+
+			res, err := client.Do(req)
+			require.NoError(t, err)
+			res.Body.Close()
+
+			require.Equal(t, http.StatusServiceUnavailable, res.StatusCode, method)
+			require.Equal(t, int32(2), atomic.LoadInt32(calls), method)
+		}
+	})
+
+	t.Run("Stop waiting when the context is canceled", func(t *testing.T) {
+		t.Parallel()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Retry-After", "60")
+			w.WriteHeader(http.StatusTooManyRequests)
+		}))
+		defer server.Close()
+
+		client := &http.Client{
+			Transport: &retryTransport{
+				base:       http.DefaultTransport,
+				maxRetries: 3,
+				maxWait:    time.Minute,
+			},
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+		defer cancel()
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+		require.NoError(t, err)
+
+		start := time.Now()
+		_, err = client.Do(req)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Less(t, time.Since(start), 5*time.Second)
+	})
+}
@@ -0,0 +1,269 @@
+package pihole
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// defaultSessionValidity is the Pi-hole default session timeout, used when the login response does not include one
+	defaultSessionValidity = 30 * time.Minute
+	// sessionRefreshMargin is how long before the end of its validity a session is renewed
+	sessionRefreshMargin = 30 * time.Second
+)
+
+// session is the Pi-hole API session, shared by all copies of a Client and safe for concurrent use
+type session struct {
+	// loginMu serializes logins and logouts so that concurrent requests share a single new session
+	loginMu sync.Mutex
+	// totpStep is the time step of the last TOTP code sent, Pi-hole rejects a code which was already used.
+	// It is guarded by loginMu.
+	totpStep uint64
+	// now returns the current time the TOTP codes are generated at
+	now func() time.Time
+
+	// mu guards the fields below
+	mu   sync.Mutex
+	id   string
+	csrf string
+	// appSudo indicates whether an application password session is allowed to write
+	appSudo bool
+	// validity is the duration of inactivity after which Pi-hole expires the session
+	validity time.Duration
+	// expires is the time at which the session expires unless it is used
+	expires time.Time
+}
+
+// set replaces the session with the one returned by a successful login
+func (s *session) set(id string, csrf string, validity time.Duration) {
+	if validity <= 0 {
+		validity = defaultSessionValidity
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.id = id
+	s.csrf = csrf
+	s.appSudo = false
+	s.validity = validity
+	s.expires = time.Now().Add(validity)
+}
+
+// setAppSudo records whether the session is allowed to write
+func (s *session) setAppSudo(appSudo bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.appSudo = appSudo
+}
+
+// credentials returns the session ID and CSRF token to authenticate requests with
+func (s *session) credentials() (string, string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.id, s.csrf
+}
+
+// canWrite indicates whether an application password session is allowed to write
+func (s *session) canWrite() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.appSudo
+}
+
+// clear forgets the session
+func (s *session) clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.id = ""
+	s.csrf = ""
+	s.appSudo = false
+	s.validity = 0
+	s.expires = time.Time{}
+}
+
+// clearIf forgets the session if it is still the passed one, i.e. no other request already replaced it
+func (s *session) clearIf(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.id == id {
+		s.id = ""
+		s.csrf = ""
+	}
+}
+
+// touch extends the session validity after it was successfully used, as Pi-hole does server side
+func (s *session) touch() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.id != "" {
+		s.expires = time.Now().Add(s.validity)
+	}
+}
+
+// usable indicates whether the session exists and is not about to expire
+func (s *session) usable() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.id == "" {
+		return false
+	}
+
+	margin := sessionRefreshMargin
+	if s.validity/2 < margin {
+		margin = s.validity / 2
+	}
+
+	return time.Now().Add(margin).Before(s.expires)
+}
+
+// ensureSession logs in if there is no session yet, and renews the session before its validity runs out
+func (c Client) ensureSession(ctx context.Context) error {
+	if c.session.usable() {
+		return nil
+	}
+
+	c.session.loginMu.Lock()
+	defer c.session.loginMu.Unlock()
+
+	// Another request may have logged in while waiting for the lock
+	if c.session.usable() {
+		return nil
+	}
+
+	previousID, previousCSRF := c.session.credentials()
+	if err := c.startSession(ctx); err != nil {
+		return err
+	}
+
+	if previousID != "" {
+		// The previous session may still be valid, release it rather than waiting for Pi-hole to expire it
+		_ = c.deleteSession(ctx, previousID, previousCSRF)
+	}
+
+	return nil
+}
+
+// Logout deletes the current session so it does not count against the webserver.api.max_sessions limit
+func (c Client) Logout(ctx context.Context) error {
+	c.session.loginMu.Lock()
+	defer c.session.loginMu.Unlock()
+
+	id, csrf := c.session.credentials()
+	if id == "" {
+		return nil
+	}
+
+	c.session.clear()
+
+	return c.deleteSession(ctx, id, csrf)
+}
+
+// deleteSession deletes the passed session
+func (c Client) deleteSession(ctx context.Context, id string, csrf string) error {
+	req, err := http.NewRequestWithContext(ctx, "DELETE", fmt.Sprintf("%s%s", c.URL, "/api/auth"), nil)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Add("X-FTL-SID", id)
+	req.Header.Add("X-FTL-CSRF", csrf)
+
+	if c.cfServiceToken != nil {
+		if err := c.cfServiceToken.Set(req); err != nil {
+			return err
+		}
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+
+	// 401 means the session already expired
+	if res.StatusCode != 204 && res.StatusCode != 401 {
+		return fmt.Errorf("failed to logout: %w", newAPIError(res))
+	}
+
+	return nil
+}
+
+// sessionRetryKey marks requests made while renewing a session, which must not renew it again
+type sessionRetryKey struct{}
+
+// sessionTransport extends the session validity on use and, when Pi-hole rejects a session (e.g. after a restart),
+// logs in again and retries the request once
+type sessionTransport struct {
+	base   http.RoundTripper
+	client *Client
+}
+
+// RoundTrip implements http.RoundTripper
+func (t *sessionTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	sid := req.Header.Get("X-FTL-SID")
+	if sid == "" || strings.HasSuffix(req.URL.Path, "/api/auth") {
+		return t.base.RoundTrip(req)
+	}
+
+	res, err := t.base.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusUnauthorized {
+		t.client.session.touch()
+		return res, nil
+	}
+
+	canReplay := req.Body == nil || req.Body == http.NoBody || req.GetBody != nil
+	if !canReplay || req.Context().Value(sessionRetryKey{}) != nil {
+		return res, nil
+	}
+
+	res.Body.Close()
+
+	// Another request may already have logged in again
+	t.client.session.clearIf(sid)
+
+	ctx := context.WithValue(req.Context(), sessionRetryKey{}, true)
+	if err := t.client.ensureSession(ctx); err != nil {
+		return nil, err
+	}
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+
+		retry.Body = body
+	}
+
+	id, csrf := t.client.session.credentials()
+	retry.Header.Set("X-FTL-SID", id)
+	retry.Header.Set("X-FTL-CSRF", csrf)
+
+	res, err = t.base.RoundTrip(retry)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusUnauthorized {
+		t.client.session.touch()
+	}
+
+	return res, nil
+}
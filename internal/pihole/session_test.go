@@ -0,0 +1,272 @@
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testSessionServer is a Pi-hole stand-in issuing numbered sessions and recording logins and logouts
+type testSessionServer struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	logins   int
+	logouts  []string
+	sessions map[string]bool
+}
+
+func newTestSessionServer(t *testing.T, validity int) *testSessionServer {
+	s := &testSessionServer{
+		sessions: map[string]bool{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if r.Method == http.MethodDelete {
+			sid := r.Header.Get("X-FTL-SID")
+			if !s.sessions[sid] {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			delete(s.sessions, sid)
+			s.logouts = append(s.logouts, sid)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		s.logins++
+		sid := fmt.Sprintf("sid-%d", s.logins)
+		s.sessions[sid] = true
+
+		w.Write([]byte(fmt.Sprintf(`{"session":{"valid":true,"sid":%q,"csrf":"csrf","validity":%d}}`, sid, validity))) //nolint:errcheck
+	})
+	mux.HandleFunc("/api/groups", func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		if !s.sessions[r.Header.Get("X-FTL-SID")] {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		w.Write([]byte(`{"groups":[]}`)) //nolint:errcheck
+	})
+
+	s.Server = httptest.NewServer(mux)
+	t.Cleanup(s.Close)
+
+	return s
+}
+
+// expireAll simulates a Pi-hole restart, which drops all sessions
+func (s *testSessionServer) expireAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.sessions = map[string]bool{}
+}
+
+func TestSession(t *testing.T) {
+	t.Run("Reuse the session across requests and client copies", func(t *testing.T) {
+		t.Parallel()
+
+		server := newTestSessionServer(t, 300)
+		client := New(Config{Password: "test", URL: server.URL})
+
+		copied := *client
+		for i := 0; i < 3; i++ {
+			_, err := copied.ListGroups(context.Background())
+			require.NoError(t, err)
+		}
+
+		_, err := client.ListGroups(context.Background())
+		require.NoError(t, err)
+
+		require.Equal(t, 1, server.logins)
+		require.Equal(t, "sid-1", client.session.id)
+	})
+
+	t.Run("Extend the session validity on use", func(t *testing.T) {
+		t.Parallel()
+
+		server := newTestSessionServer(t, 300)
+		client := New(Config{Password: "test", URL: server.URL})
+
+		require.NoError(t, client.Login(context.Background()))
+		client.session.expires = time.Now().Add(time.Minute)
+
+		_, err := client.ListGroups(context.Background())
+		require.NoError(t, err)
+		require.WithinDuration(t, time.Now().Add(300*time.Second), client.session.expires, 5*time.Second)
+	})
+
+	t.Run("Renew the session before its validity runs out", func(t *testing.T) {
+		t.Parallel()
+
+		server := newTestSessionServer(t, 300)
+		client := New(Config{Password: "test", URL: server.URL})
+
+		require.NoError(t, client.Login(context.Background()))
+		client.session.expires = time.Now().Add(10 * time.Second)
+
+		_, err := client.ListGroups(context.Background())
+		require.NoError(t, err)
+
+		require.Equal(t, 2, server.logins)
+		require.Equal(t, "sid-2", client.session.id)
+		require.Equal(t, []string{"sid-1"}, server.logouts)
+	})
+
+	t.Run("Log in again once when the session is rejected", func(t *testing.T) {
+		t.Parallel()
+
+		server := newTestSessionServer(t, 300)
+		client := New(Config{Password: "test", URL: server.URL})
+
+		require.NoError(t, client.Login(context.Background()))
+		server.expireAll()
+
+		_, err := client.ListGroups(context.Background())
+		require.NoError(t, err)
+
+		require.Equal(t, 2, server.logins)
+		require.Equal(t, "sid-2", client.session.id)
+	})
+
+	t.Run("Do not retry more than once", func(t *testing.T) {
+		t.Parallel()
+
+		var logins int
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+			logins++
+			w.Write([]byte(`{"session":{"valid":true,"sid":"sid","csrf":"csrf","validity":300}}`)) //nolint:errcheck
+		})
+		mux.HandleFunc("/api/groups", func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+		})
+
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		client := New(Config{Password: "test", URL: server.URL})
+
+		_, err := client.ListGroups(context.Background())
+		var apiErr *APIError
+		require.ErrorAs(t, err, &apiErr)
+		require.Equal(t, http.StatusUnauthorized, apiErr.StatusCode)
+		require.Equal(t, 2, logins)
+	})
+
+	t.Run("Log out", func(t *testing.T) {
+		t.Parallel()
+
+		server := newTestSessionServer(t, 300)
+		client := New(Config{Password: "test", URL: server.URL})
+
+		require.NoError(t, client.Login(context.Background()))
+		require.NoError(t, client.Logout(context.Background()))
+
+		require.Equal(t, []string{"sid-1"}, server.logouts)
+		require.Empty(t, client.session.id)
+
+		// Logging out without a session is a no-op
+		require.NoError(t, client.Logout(context.Background()))
+	})
+}
+
+func TestClientConcurrency(t *testing.T) {
+	t.Run("Share a single session between concurrent requests", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			mu     sync.Mutex
+			logins int
+			groups []string
+		)
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			logins++
+			mu.Unlock()
+
+			// Widen the window in which concurrent requests find no session
+			time.Sleep(50 * time.Millisecond)
+
+			w.Write([]byte(`{"session":{"valid":true,"sid":"sid","csrf":"csrf","validity":300}}`)) //nolint:errcheck
+		})
+		mux.HandleFunc("/api/groups", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "sid", r.Header.Get("X-FTL-SID"))
+
+			mu.Lock()
+			defer mu.Unlock()
+
+			if r.Method == http.MethodPost {
+				var body struct {
+					Name string `json:"name"`
+				}
+				if !assert.NoError(t, json.NewDecoder(r.Body).Decode(&body)) {
+					w.WriteHeader(http.StatusBadRequest)
+					return
+				}
+
+				groups = append(groups, body.Name)
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{}`)) //nolint:errcheck
+				return
+			}
+
+			list := make([]map[string]any, len(groups))
+			for i, name := range groups {
+				list[i] = map[string]any{"id": i + 1, "name": name, "enabled": true}
+			}
+			json.NewEncoder(w).Encode(map[string]any{"groups": list}) //nolint:errcheck
+		})
+
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		client := New(Config{Password: "test", URL: server.URL})
+		require.NoError(t, client.Init(context.Background()))
+
+		var wg sync.WaitGroup
+		for i := 0; i < 50; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+
+				name := fmt.Sprintf("group-%d", i)
+				if i%2 == 0 {
+					_, err := client.CreateGroup(context.Background(), &GroupCreateRequest{Name: name})
+					assert.NoError(t, err)
+					return
+				}
+
+				_, err := client.ListGroups(context.Background())
+				assert.NoError(t, err)
+			}(i)
+		}
+		wg.Wait()
+
+		mu.Lock()
+		defer mu.Unlock()
+
+		require.Equal(t, 1, logins)
+		require.Len(t, groups, 25)
+	})
+}
@@ -0,0 +1,177 @@
+package pihole
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime"
+	"sort"
+)
+
+type TeleporterBackup struct {
+	// Filename is the name suggested by Pi-hole for the archive, e.g. pi-hole_pihole_teleporter_2025-01-01_00-00-00_UTC.zip
+	Filename string
+	// Data is the raw zip archive
+	Data []byte
+	// SHA256 is the hex encoded SHA-256 checksum of Data
+	SHA256 string
+	// Files lists the files contained in the archive
+	Files []string
+}
+
+// GetTeleporterBackup downloads a Teleporter backup archive of the Pi-hole configuration
+func (c Client) GetTeleporterBackup(ctx context.Context) (*TeleporterBackup, error) {
+	req, err := c.RequestWithSession2(ctx, "GET", "/api/teleporter", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/zip")
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to retrieve teleporter backup: %w", newAPIError(res))
+	}
+
+	defer res.Body.Close()
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	files, err := listZipFiles(b)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read teleporter backup: %s", err)
+	}
+
+	filename := "pihole-teleporter.zip"
+	if _, params, err := mime.ParseMediaType(res.Header.Get("Content-Disposition")); err == nil && params["filename"] != "" {
+		filename = params["filename"]
+	}
+
+	hash := sha256.Sum256(b)
+
+	return &TeleporterBackup{
+		Filename: filename,
+		Data:     b,
+		SHA256:   fmt.Sprintf("%x", hash[:]),
+		Files:    files,
+	}, nil
+}
+
+// listZipFiles returns the sorted names of the files contained in a zip archive
+func listZipFiles(data []byte) ([]string, error) {
+	reader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, 0, len(reader.File))
+	for _, f := range reader.File {
+		if f.FileInfo().IsDir() {
+			continue
+		}
+
+		files = append(files, f.Name)
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// TeleporterGravityTables lists the gravity database tables which can be selectively restored from a Teleporter archive
+var TeleporterGravityTables = []string{
+	"group",
+	"adlist",
+	"adlist_by_group",
+	"domainlist",
+	"domainlist_by_group",
+	"client",
+	"client_by_group",
+}
+
+// TeleporterImportOptions selects the parts of a Teleporter archive to restore
+type TeleporterImportOptions struct {
+	Config     bool
+	DHCPLeases bool
+	// GravityTables lists the gravity database tables to restore, see TeleporterGravityTables
+	GravityTables []string
+}
+
+// MarshalJSON encodes the import options in the format expected by the import field of POST /api/teleporter
+func (o TeleporterImportOptions) MarshalJSON() ([]byte, error) {
+	gravity := make(map[string]bool, len(TeleporterGravityTables))
+	for _, table := range TeleporterGravityTables {
+		gravity[table] = false
+	}
+
+	for _, table := range o.GravityTables {
+		if _, ok := gravity[table]; !ok {
+			return nil, fmt.Errorf("unknown gravity table %q", table)
+		}
+
+		gravity[table] = true
+	}
+
+	return json.Marshal(map[string]any{
+		"config":      o.Config,
+		"dhcp_leases": o.DHCPLeases,
+		"gravity":     gravity,
+	})
+}
+
+// RestoreTeleporterBackup uploads a Teleporter archive, restoring everything it contains unless import options are passed.
+// It returns the files processed by Pi-hole.
+func (c Client) RestoreTeleporterBackup(ctx context.Context, filename string, data []byte, options *TeleporterImportOptions) ([]string, error) {
+	fields := map[string]string{}
+	if options != nil {
+		b, err := json.Marshal(options)
+		if err != nil {
+			return nil, err
+		}
+
+		fields["import"] = string(b)
+	}
+
+	req, err := c.RequestWithSessionMultipart(ctx, "POST", "/api/teleporter", fields, []MultipartFile{
+		{
+			Field:    "file",
+			Filename: filename,
+			Data:     data,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to restore teleporter backup: %w", newAPIError(res))
+	}
+
+	defer res.Body.Close()
+	type Response struct {
+		Files []string `json:"files"`
+	}
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	var response Response
+	if err := json.Unmarshal(b, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Files, nil
+}
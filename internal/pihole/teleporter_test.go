@@ -0,0 +1,195 @@
+package pihole
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testTeleporterArchive(t *testing.T, files ...string) []byte {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, f := range files {
+		fw, err := w.Create(f)
+		require.NoError(t, err)
+
+		_, err = fw.Write([]byte(f))
+		require.NoError(t, err)
+	}
+	require.NoError(t, w.Close())
+
+	return buf.Bytes()
+}
+
+func TestGetTeleporterBackup(t *testing.T) {
+	t.Run("Download the archive and list its files", func(t *testing.T) {
+		t.Parallel()
+
+		archive := testTeleporterArchive(t, "etc/pihole/pihole.toml", "etc/pihole/gravity.db", "etc/hosts")
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"session":{"valid":true,"sid":"sid","csrf":"csrf","validity":300}}`)) //nolint:errcheck
+		})
+		mux.HandleFunc("/api/teleporter", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "GET", r.Method)
+			assert.Equal(t, "sid", r.Header.Get("X-FTL-SID"))
+
+			w.Header().Set("Content-Type", "application/zip")
+			w.Header().Set("Content-Disposition", `attachment; filename="pi-hole_pihole_teleporter.zip"`)
+			w.Write(archive) //nolint:errcheck
+		})
+
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		client := New(Config{
+			Password: "test",
+			URL:      server.URL,
+		})
+
+		backup, err := client.GetTeleporterBackup(context.Background())
+		require.NoError(t, err)
+
+		hash := sha256.Sum256(archive)
+		require.Equal(t, archive, backup.Data)
+		require.Equal(t, fmt.Sprintf("%x", hash[:]), backup.SHA256)
+		require.Equal(t, "pi-hole_pihole_teleporter.zip", backup.Filename)
+		require.Equal(t, []string{"etc/hosts", "etc/pihole/gravity.db", "etc/pihole/pihole.toml"}, backup.Files)
+	})
+
+	t.Run("Fail if the response is not a zip archive", func(t *testing.T) {
+		t.Parallel()
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"session":{"valid":true,"sid":"sid","csrf":"csrf","validity":300}}`)) //nolint:errcheck
+		})
+		mux.HandleFunc("/api/teleporter", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"error":{"key":"bad_request"}}`)) //nolint:errcheck
+		})
+
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		client := New(Config{
+			Password: "test",
+			URL:      server.URL,
+		})
+
+		_, err := client.GetTeleporterBackup(context.Background())
+		require.ErrorContains(t, err, "failed to read teleporter backup")
+	})
+}
+
+func TestRestoreTeleporterBackup(t *testing.T) {
+	t.Run("Upload the archive with selective import options", func(t *testing.T) {
+		t.Parallel()
+
+		archive := testTeleporterArchive(t, "etc/pihole/pihole.toml", "etc/pihole/gravity.db")
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"session":{"valid":true,"sid":"sid","csrf":"csrf","validity":300}}`)) //nolint:errcheck
+		})
+		mux.HandleFunc("/api/teleporter", func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "POST", r.Method)
+			assert.Equal(t, "sid", r.Header.Get("X-FTL-SID"))
+			if !assert.NoError(t, r.ParseMultipartForm(1<<20)) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			assert.JSONEq(t, `{
+				"config": true,
+				"dhcp_leases": false,
+				"gravity": {
+					"group": true,
+					"adlist": true,
+					"adlist_by_group": false,
+					"domainlist": false,
+					"domainlist_by_group": false,
+					"client": false,
+					"client_by_group": false
+				}
+			}`, r.FormValue("import"))
+
+			f, header, err := r.FormFile("file")
+			if !assert.NoError(t, err) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			defer f.Close()
+
+			b, err := io.ReadAll(f)
+			assert.NoError(t, err)
+			assert.Equal(t, "backup.zip", header.Filename)
+			assert.Equal(t, archive, b)
+
+			w.Write([]byte(`{"files":["etc/pihole/pihole.toml","etc/pihole/gravity.db"],"took":0.1}`)) //nolint:errcheck
+		})
+
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		client := New(Config{
+			Password: "test",
+			URL:      server.URL,
+		})
+
+		files, err := client.RestoreTeleporterBackup(context.Background(), "backup.zip", archive, &TeleporterImportOptions{
+			Config:        true,
+			GravityTables: []string{"group", "adlist"},
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"etc/pihole/pihole.toml", "etc/pihole/gravity.db"}, files)
+	})
+
+	t.Run("Omit import options to restore everything", func(t *testing.T) {
+		t.Parallel()
+
+		mux := http.NewServeMux()
+		mux.HandleFunc("/api/auth", func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"session":{"valid":true,"sid":"sid","csrf":"csrf","validity":300}}`)) //nolint:errcheck
+		})
+		mux.HandleFunc("/api/teleporter", func(w http.ResponseWriter, r *http.Request) {
+			if !assert.NoError(t, r.ParseMultipartForm(1<<20)) {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			assert.NotContains(t, r.MultipartForm.Value, "import")
+
+			w.Write([]byte(`{"files":[]}`)) //nolint:errcheck
+		})
+
+		server := httptest.NewServer(mux)
+		defer server.Close()
+
+		client := New(Config{
+			Password: "test",
+			URL:      server.URL,
+		})
+
+		_, err := client.RestoreTeleporterBackup(context.Background(), "backup.zip", testTeleporterArchive(t, "etc/hosts"), nil)
+		require.NoError(t, err)
+	})
+
+	t.Run("Reject unknown gravity tables", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := json.Marshal(TeleporterImportOptions{GravityTables: []string{"gravity"}})
+		require.ErrorContains(t, err, "unknown gravity table")
+	})
+}
@@ -0,0 +1,89 @@
+package pihole
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// totpPeriod is the time step of the TOTP codes accepted by Pi-hole
+	totpPeriod = 30 * time.Second
+	// totpDigits is the number of digits of the TOTP codes accepted by Pi-hole
+	totpDigits = 6
+)
+
+// decodeTOTPSecret decodes a base32 TOTP secret, ignoring case, spaces and padding
+func decodeTOTPSecret(secret string) ([]byte, error) {
+	secret = strings.ToUpper(strings.ReplaceAll(secret, " ", ""))
+	secret = strings.TrimRight(secret, "=")
+
+	key, err := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	if err != nil {
+		return nil, fmt.Errorf("invalid TOTP secret: %s", err)
+	}
+
+	if len(key) == 0 {
+		return nil, fmt.Errorf("invalid TOTP secret: secret is empty")
+	}
+
+	return key, nil
+}
+
+// totpStep returns the TOTP time step of the passed time
+func totpStep(t time.Time) uint64 {
+	return uint64(t.Unix() / int64(totpPeriod/time.Second))
+}
+
+// nextTOTPTime returns the time to generate the next TOTP code at. Pi-hole accepts each code only once, so if the
+// code of the current time step was already sent it waits for the next time step.
+func nextTOTPTime(ctx context.Context, now func() time.Time, lastStep uint64) (time.Time, error) {
+	t := now()
+	if lastStep == 0 || totpStep(t) > lastStep {
+		return t, nil
+	}
+
+	next := time.Unix(int64(lastStep+1)*int64(totpPeriod/time.Second), 0)
+
+	timer := time.NewTimer(next.Sub(t))
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return time.Time{}, fmt.Errorf("failed to wait for the next TOTP code: %w", ctx.Err())
+	case <-timer.C:
+	}
+
+	// The clock may be behind the timer, never send the code of the previous step again
+	if t = now(); totpStep(t) <= lastStep {
+		t = next
+	}
+
+	return t, nil
+}
+
+// totpCode generates the RFC 6238 time-based one-time password (HMAC-SHA1, 30 second period) of the key at the passed time
+func totpCode(key []byte, t time.Time, digits int) uint32 {
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, totpStep(t))
+
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter) //nolint:errcheck
+	sum := mac.Sum(nil)
+
+	// Dynamic truncation as described in RFC 4226 section 5.3
+	offset := sum[len(sum)-1] & 0x0f
+	code := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	mod := uint32(1)
+	for i := 0; i < digits; i++ {
+		mod *= 10
+	}
+
+	return code % mod
+}
@@ -0,0 +1,53 @@
+package pihole
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTOTP(t *testing.T) {
+	t.Run("Generate RFC 6238 SHA1 test vectors", func(t *testing.T) {
+		t.Parallel()
+
+		// base32 of the RFC 6238 SHA1 seed "12345678901234567890"
+		key, err := decodeTOTPSecret("GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ")
+		require.NoError(t, err)
+
+		for unix, expected := range map[int64]uint32{
+			59:          94287082,
+			1111111109:  7081804,
+			1111111111:  14050471,
+			1234567890:  89005924,
+			2000000000:  69279037,
+			20000000000: 65353130,
+		} {
+			require.Equal(t, expected, totpCode(key, time.Unix(unix, 0), 8), unix)
+		}
+
+		require.Equal(t, uint32(287082), totpCode(key, time.Unix(59, 0), totpDigits))
+	})
+
+	t.Run("Decode secrets regardless of case, spaces and padding", func(t *testing.T) {
+		t.Parallel()
+
+		expected, err := decodeTOTPSecret("JBSWY3DPEHPK3PXP")
+		require.NoError(t, err)
+
+		for _, secret := range []string{"jbswy3dpehpk3pxp", "JBSW Y3DP EHPK 3PXP", "JBSWY3DPEHPK3PXP===="} {
+			key, err := decodeTOTPSecret(secret)
+			require.NoError(t, err, secret)
+			require.Equal(t, expected, key, secret)
+		}
+	})
+
+	t.Run("Reject invalid secrets", func(t *testing.T) {
+		t.Parallel()
+
+		for _, secret := range []string{"", "not-base32!", "01234567"} {
+			_, err := decodeTOTPSecret(secret)
+			require.Error(t, err, secret)
+		}
+	})
+}
@@ -0,0 +1,101 @@
+package pihole
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/netip"
+	"strconv"
+	"strings"
+)
+
+// ValidateUpstream checks that an upstream DNS server is an IP address with an optional port, e.g. 9.9.9.9 or 127.0.0.1#5335
+func ValidateUpstream(upstream string) error {
+	addr, port, hasPort := strings.Cut(upstream, "#")
+
+	if _, err := netip.ParseAddr(addr); err != nil {
+		return fmt.Errorf("invalid upstream %q: %q is not an IP address", upstream, addr)
+	}
+
+	if !hasPort {
+		return nil
+	}
+
+	p, err := strconv.Atoi(port)
+	if err != nil || p < 1 || p > 65535 {
+		return fmt.Errorf("invalid upstream %q: %q is not a valid port", upstream, port)
+	}
+
+	return nil
+}
+
+// ListUpstreams returns the ordered list of upstream DNS servers configured in pihole
+func (c Client) ListUpstreams(ctx context.Context) ([]string, error) {
+	req, err := c.RequestWithSession2(ctx, "GET", "/api/config/dns/upstreams", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to retrieve upstreams: %w", newAPIError(res))
+	}
+
+	defer res.Body.Close()
+	type Response struct {
+		Config struct {
+			DNS struct {
+				Upstreams []string `json:"upstreams"`
+			} `json:"dns"`
+		} `json:"config"`
+	}
+	b, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	var response Response
+	if err := json.Unmarshal(b, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Config.DNS.Upstreams, nil
+}
+
+// SetUpstreams replaces the upstream DNS servers with the passed ordered list in a single request
+func (c Client) SetUpstreams(ctx context.Context, upstreams []string) ([]string, error) {
+	for _, u := range upstreams {
+		if err := ValidateUpstream(u); err != nil {
+			return nil, err
+		}
+	}
+
+	if upstreams == nil {
+		upstreams = []string{}
+	}
+
+	req, err := c.RequestWithSession2(ctx, "PATCH", "/api/config", map[string]any{
+		"config": map[string]any{
+			"dns": map[string]any{
+				"upstreams": upstreams,
+			},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != 200 {
+		return nil, fmt.Errorf("failed to set upstreams: %w", newAPIError(res))
+	}
+	defer res.Body.Close()
+
+	return c.ListUpstreams(ctx)
+}
@@ -0,0 +1,25 @@
+package pihole
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateUpstream(t *testing.T) {
+	t.Run("Accept IP addresses with optional ports", func(t *testing.T) {
+		t.Parallel()
+
+		for _, upstream := range []string{"9.9.9.9", "127.0.0.1#5335", "2620:fe::fe", "2620:fe::fe#53"} {
+			require.NoError(t, ValidateUpstream(upstream), upstream)
+		}
+	})
+
+	t.Run("Reject invalid upstreams", func(t *testing.T) {
+		t.Parallel()
+
+		for _, upstream := range []string{"", "dns.quad9.net", "9.9.9.9#", "9.9.9.9#0", "9.9.9.9#65536", "9.9.9.9:53", "[2620:fe::fe]#53"} {
+			require.Error(t, ValidateUpstream(upstream), upstream)
+		}
+	})
+}
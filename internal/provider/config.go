@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"time"
 
 	"github.com/iolave/go-proxmox/pkg/cloudflare"
 	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
@@ -26,6 +27,21 @@ type Config struct {
 	// Pi-hole API token
 	APIToken string
 
+	// Base32 encoded TOTP secret for two-factor authentication
+	TOTPSecret string
+
+	// Number of retries of requests rejected with 429, 502, 503 or 504
+	MaxRetries int
+
+	// Maximum wait between two attempts of a retried request
+	RetryMaxWait time.Duration
+
+	// Maximum number of requests per second, 0 disables the limit
+	RequestsPerSecond float64
+
+	// Number of requests which can be sent at once
+	Burst int
+
 	// Custom CA file
 	CAFile         string
 	CFServiceToken *cloudflare.ServiceToken
@@ -54,12 +70,17 @@ func (c Config) Client(ctx context.Context) (*pihole.Client, error) {
 	}
 
 	config := pihole.Config{
-		URL:            c.URL,
-		Password:       c.Password,
-		UserAgent:      c.UserAgent,
-		APIToken:       c.APIToken,
-		Client:         HttpClient,
-		CFServiceToken: c.CFServiceToken,
+		URL:               c.URL,
+		Password:          c.Password,
+		UserAgent:         c.UserAgent,
+		APIToken:          c.APIToken,
+		TOTPSecret:        c.TOTPSecret,
+		MaxRetries:        c.MaxRetries,
+		RetryMaxWait:      c.RetryMaxWait,
+		RequestsPerSecond: c.RequestsPerSecond,
+		Burst:             c.Burst,
+		Client:            HttpClient,
+		CFServiceToken:    c.CFServiceToken,
 	}
 
 	client := pihole.New(config)
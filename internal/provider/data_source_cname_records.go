@@ -31,6 +31,11 @@ func dataSourceCNAMERecords() *schema.Resource {
 							Type:        schema.TypeString,
 							Computed:    true,
 						},
+						"ttl": {
+							Description: "Time to live of the CNAME record in seconds, 0 when Pi-hole uses its default TTL",
+							Type:        schema.TypeInt,
+							Computed:    true,
+						},
 					},
 				},
 			},
@@ -54,11 +59,12 @@ func dataSourceCNAMERecordsRead(ctx context.Context, d *schema.ResourceData, met
 	idRef := ""
 
 	for i, r := range cnameList {
-		idRef = fmt.Sprintf("%s%s%s", idRef, r.Domain, r.Target)
+		idRef = fmt.Sprintf("%s%s%s%d", idRef, r.Domain, r.Target, r.TTL)
 
 		list[i] = map[string]interface{}{
 			"domain": r.Domain,
 			"target": r.Target,
+			"ttl":    r.TTL,
 		}
 	}
 
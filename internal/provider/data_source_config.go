@@ -0,0 +1,82 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
+)
+
+// dataSourceConfig returns the Pi-hole configuration tree, or one section of it
+func dataSourceConfig() *schema.Resource {
+	return &schema.Resource{
+		Description: "Reads the Pi-hole configuration, or one section of it",
+		ReadContext: dataSourceConfigRead,
+		Schema: map[string]*schema.Schema{
+			"section": {
+				Type:        schema.TypeString,
+				Description: "Dotted path of the config section to read, e.g. `dns` or `dns.blocking`. The whole configuration is read when unset.",
+				Optional:    true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					if err := pihole.ValidateConfigPath(val.(string)); err != nil {
+						errs = append(errs, err)
+					}
+
+					return
+				},
+			},
+			"json": {
+				Type:        schema.TypeString,
+				Description: "JSON encoded configuration tree, starting at the root even when `section` is set",
+				Computed:    true,
+			},
+			"values": {
+				Type:        schema.TypeMap,
+				Description: "Configuration values keyed by dotted path, e.g. `dns.domain`. Strings are kept as is, all other values are JSON encoded.",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// dataSourceConfigRead reads the Pi-hole configuration
+func dataSourceConfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	config, err := client.GetConfig(ctx, d.Get("section").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	values, err := pihole.FlattenConfig(config)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	configString, err := normalizeJSON(string(config))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("json", configString); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("values", values); err != nil {
+		return diag.FromErr(err)
+	}
+
+	hash := sha256.Sum256([]byte(configString))
+	d.SetId(fmt.Sprintf("%x", hash[:]))
+
+	return diags
+}
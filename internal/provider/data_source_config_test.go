@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccConfigData(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheckLive(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "pihole_config_setting" "mode" {
+					  path  = "dns.blocking.mode"
+					  value = jsonencode("NXDOMAIN")
+					}
+
+					data "pihole_config" "all" {
+					  depends_on = [pihole_config_setting.mode]
+					}
+
+					data "pihole_config" "blocking" {
+					  section    = "dns.blocking"
+					  depends_on = [pihole_config_setting.mode]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_config.all", "values.dns.blocking.mode", "NXDOMAIN"),
+					resource.TestCheckResourceAttrSet("data.pihole_config.all", "values.dhcp.active"),
+					resource.TestCheckResourceAttrSet("data.pihole_config.all", "json"),
+
+					resource.TestCheckResourceAttr("data.pihole_config.blocking", "values.dns.blocking.mode", "NXDOMAIN"),
+					resource.TestCheckNoResourceAttr("data.pihole_config.blocking", "values.dhcp.active"),
+				),
+			},
+		},
+	})
+}
@@ -23,7 +23,21 @@ func dataSourceDomains() *schema.Resource {
 					domainType := val.(string)
 
 					if domainType != pihole.DomainOptionsAllow && domainType != pihole.DomainOptionsDeny {
-						errs = append(errs, fmt.Errorf("%s field must be one of %v: %q", key, domainType, []string{pihole.DomainOptionsAllow, pihole.DomainOptionsDeny}))
+						errs = append(errs, fmt.Errorf("%s field must be one of %v: %q", key, []string{pihole.DomainOptionsAllow, pihole.DomainOptionsDeny}, domainType))
+					}
+
+					return
+				},
+			},
+			"kind": {
+				Type:        schema.TypeString,
+				Description: "Filter on exact or regex domains. Must be either 'exact' or 'regex'.",
+				Optional:    true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					kind := val.(string)
+
+					if kind != pihole.DomainKindExact && kind != pihole.DomainKindRegex {
+						errs = append(errs, fmt.Errorf("%s field must be one of %v: %q", key, []string{pihole.DomainKindExact, pihole.DomainKindRegex}, kind))
 					}
 
 					return
@@ -45,6 +59,11 @@ func dataSourceDomains() *schema.Resource {
 							Type:        schema.TypeString,
 							Computed:    true,
 						},
+						"kind": {
+							Description: "Whether the domain is matched exactly or as a regular expression",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
 						"enabled": {
 							Description: "Whether the domain rule is enabled",
 							Type:        schema.TypeBool,
@@ -95,6 +114,12 @@ func dataSourceDomainsRead(ctx context.Context, d *schema.ResourceData, meta int
 		opts.Type = domainType
 	}
 
+	kind := d.Get("kind").(string)
+
+	if kind != "" {
+		opts.Kind = kind
+	}
+
 	domainList, err := client.ListDomains(ctx, opts)
 	if err != nil {
 		return diag.FromErr(err)
@@ -103,9 +128,15 @@ func dataSourceDomainsRead(ctx context.Context, d *schema.ResourceData, meta int
 	list := make([]map[string]interface{}, len(domainList))
 
 	for i, d := range domainList {
+		kind := pihole.DomainKindExact
+		if d.Wildcard {
+			kind = pihole.DomainKindRegex
+		}
+
 		list[i] = map[string]interface{}{
 			"id":        d.ID,
 			"type":      d.Type,
+			"kind":      kind,
 			"enabled":   d.Enabled,
 			"domain":    d.Domain,
 			"comment":   d.Comment,
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"testing"
+)
+
+func TestAccDomainsData(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					resource "pihole_domain" "exact" {
+					  domain  = "ads.example.com"
+					  type    = "deny"
+					  comment = "exact"
+					}
+
+					resource "pihole_domain" "regex" {
+					  domain = "(\\.|^)tracking\\.example\\.com$"
+					  type   = "deny"
+					  kind   = "regex"
+					}
+
+					data "pihole_domains" "regex" {
+					  type = "deny"
+					  kind = "regex"
+
+					  depends_on = [pihole_domain.exact, pihole_domain.regex]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_domains.regex", "domains.#", "1"),
+
+					resource.TestCheckResourceAttrSet("data.pihole_domains.regex", "domains.0.id"),
+					resource.TestCheckResourceAttr("data.pihole_domains.regex", "domains.0.domain", `(\.|^)tracking\.example\.com$`),
+					resource.TestCheckResourceAttr("data.pihole_domains.regex", "domains.0.type", "deny"),
+					resource.TestCheckResourceAttr("data.pihole_domains.regex", "domains.0.kind", "regex"),
+					resource.TestCheckResourceAttr("data.pihole_domains.regex", "domains.0.wildcard", "true"),
+					resource.TestCheckResourceAttr("data.pihole_domains.regex", "domains.0.group_ids.#", "1"),
+					resource.TestCheckResourceAttr("data.pihole_domains.regex", "domains.0.group_ids.0", "0"),
+				),
+			},
+		},
+	})
+}
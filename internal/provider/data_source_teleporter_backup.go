@@ -0,0 +1,93 @@
+package provider
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
+)
+
+// dataSourceTeleporterBackup downloads a Pi-hole Teleporter backup to a local file
+func dataSourceTeleporterBackup() *schema.Resource {
+	return &schema.Resource{
+		Description: "Downloads a Teleporter backup of the Pi-hole configuration to a local zip file. A new backup is taken every time the data source is read.",
+		ReadContext: dataSourceTeleporterBackupRead,
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Type:        schema.TypeString,
+				Description: "Local path the zip archive is written to. Missing parent directories are created.",
+				Required:    true,
+			},
+			"filename": {
+				Type:        schema.TypeString,
+				Description: "File name suggested by Pi-hole for the archive",
+				Computed:    true,
+			},
+			"sha256": {
+				Type:        schema.TypeString,
+				Description: "Hex encoded SHA-256 checksum of the archive",
+				Computed:    true,
+			},
+			"size": {
+				Type:        schema.TypeInt,
+				Description: "Size of the archive in bytes",
+				Computed:    true,
+			},
+			"files": {
+				Type:        schema.TypeList,
+				Description: "Files contained in the archive",
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// dataSourceTeleporterBackupRead downloads a Teleporter backup and writes it to the configured path
+func dataSourceTeleporterBackupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	backup, err := client.GetTeleporterBackup(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	path := d.Get("path").(string)
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The archive contains the Pi-hole password hash and session secrets, keep it private
+	if err := os.WriteFile(path, backup.Data, 0o600); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("filename", backup.Filename); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("sha256", backup.SHA256); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("size", len(backup.Data)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("files", backup.Files); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(backup.SHA256)
+
+	return diags
+}
@@ -0,0 +1,57 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccTeleporterBackupData(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backups", "pihole.zip")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheckLive(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					data "pihole_teleporter_backup" "backup" {
+					  path = %q
+					}
+				`, path),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.pihole_teleporter_backup.backup", "path", path),
+					resource.TestCheckResourceAttrSet("data.pihole_teleporter_backup.backup", "filename"),
+					resource.TestCheckResourceAttrSet("data.pihole_teleporter_backup.backup", "files.0"),
+					testCheckTeleporterBackupFile("data.pihole_teleporter_backup.backup", path),
+				),
+			},
+		},
+	})
+}
+
+func testCheckTeleporterBackupFile(name, path string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("%s not found in state", name)
+		}
+
+		b, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		hash := sha256.Sum256(b)
+		if checksum := fmt.Sprintf("%x", hash[:]); checksum != rs.Primary.Attributes["sha256"] {
+			return fmt.Errorf("checksum of %s %s does not match sha256 attribute %s", path, checksum, rs.Primary.Attributes["sha256"])
+		}
+
+		return nil
+	}
+}
@@ -3,13 +3,62 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/iolave/go-proxmox/pkg/cloudflare"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
 	"github.com/ryanwholey/terraform-provider-pihole/internal/version"
 )
 
+// logoutTimeout bounds the logout requests made on shutdown, Terraform kills providers which do not exit within 2s
+const logoutTimeout = time.Second
+
+// configuredClients holds the clients configured by the provider process, whose sessions are logged out on shutdown
+var configuredClients clientRegistry
+
+// clientRegistry is a list of clients safe for concurrent use
+type clientRegistry struct {
+	mu      sync.Mutex
+	clients []*pihole.Client
+}
+
+// add registers a configured client
+func (r *clientRegistry) add(client *pihole.Client) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.clients = append(r.clients, client)
+}
+
+// take returns the registered clients and forgets them
+func (r *clientRegistry) take() []*pihole.Client {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	clients := r.clients
+	r.clients = nil
+
+	return clients
+}
+
+// Shutdown logs out the Pi-hole sessions of the provider process so they do not count against
+// webserver.api.max_sessions until they expire. It is called once the plugin server stopped.
+func Shutdown(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, logoutTimeout)
+	defer cancel()
+
+	for _, client := range configuredClients.take() {
+		if err := client.Logout(ctx); err != nil {
+			log.Printf("[WARN] failed to log out of Pi-hole: %s", err)
+		}
+	}
+}
+
 func Provider() *schema.Provider {
 	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
@@ -30,15 +79,78 @@ func Provider() *schema.Provider {
 				Type:         schema.TypeString,
 				Optional:     true,
 				DefaultFunc:  schema.EnvDefaultFunc("PIHOLE_API_TOKEN", nil),
-				Description:  "Experimental: Pi-hole API token. Conflicts with `password`.",
+				Description:  "Pi-hole application password, generated in the web interface under Settings > Web interface / API. Changes require `webserver.api.app_sudo` to be enabled. Conflicts with `password`.",
 				ExactlyOneOf: []string{"api_token", "password"},
 			},
+			"totp_secret": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Sensitive:     true,
+				DefaultFunc:   schema.EnvDefaultFunc("PIHOLE_TOTP_SECRET", nil),
+				Description:   "Base32 encoded TOTP secret used to generate two-factor authentication codes when 2FA is enabled on the Pi-hole. Conflicts with `api_token`.",
+				ConflictsWith: []string{"api_token"},
+			},
 			"ca_file": {
 				Type:        schema.TypeString,
 				Optional:    true,
 				DefaultFunc: schema.EnvDefaultFunc("PIHOLE_CA_FILE", nil),
 				Description: "CA file to connect to Pi-hole with TLS",
 			},
+			"max_retries": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     3,
+				Description: "Number of times a request rejected with a 429, 502, 503 or 504 status code is retried. Writes (POST, PUT, PATCH) are only retried on 429. Set to 0 to disable retries.",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					if val.(int) < 0 {
+						errs = append(errs, fmt.Errorf("%s field must not be negative: %d", key, val.(int)))
+					}
+
+					return
+				},
+			},
+			"retry_max_wait": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "30s",
+				Description: "Maximum wait between two attempts of a retried request, e.g. `30s` or `2m`. Caps both the exponential backoff and the `Retry-After` header.",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					wait, err := time.ParseDuration(val.(string))
+					if err != nil {
+						errs = append(errs, fmt.Errorf("%s field must be a valid duration: %s", key, err))
+					} else if wait <= 0 {
+						errs = append(errs, fmt.Errorf("%s field must be positive: %q", key, val.(string)))
+					}
+
+					return
+				},
+			},
+			"requests_per_second": {
+				Type:        schema.TypeFloat,
+				Optional:    true,
+				Default:     0,
+				Description: "Maximum number of requests per second sent to Pi-hole, including logins and retries. Set to 0 to disable the limit.",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					if val.(float64) < 0 {
+						errs = append(errs, fmt.Errorf("%s field must not be negative: %v", key, val.(float64)))
+					}
+
+					return
+				},
+			},
+			"burst": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     1,
+				Description: "Number of requests which can be sent at once before `requests_per_second` applies",
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					if val.(int) < 1 {
+						errs = append(errs, fmt.Errorf("%s field must be at least 1: %d", key, val.(int)))
+					}
+
+					return
+				},
+			},
 			"cf_access_client_id": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -54,17 +166,28 @@ func Provider() *schema.Provider {
 		},
 
 		DataSourcesMap: map[string]*schema.Resource{
-			"pihole_cname_records": dataSourceCNAMERecords(),
-			"pihole_dns_records":   dataSourceDNSRecords(),
-			"pihole_domains":       dataSourceDomains(),
-			"pihole_groups":        dataSourceGroups(),
+			"pihole_cname_records":     dataSourceCNAMERecords(),
+			"pihole_config":            dataSourceConfig(),
+			"pihole_dns_records":       dataSourceDNSRecords(),
+			"pihole_domains":           dataSourceDomains(),
+			"pihole_groups":            dataSourceGroups(),
+			"pihole_teleporter_backup": dataSourceTeleporterBackup(),
 		},
 
 		ResourcesMap: map[string]*schema.Resource{
-			"pihole_ad_blocker_status": resourceAdBlockerStatus(),
-			"pihole_cname_record":      resourceCNAMERecord(),
-			"pihole_dns_record":        resourceDNSRecord(),
-			"pihole_group":             resourceGroup(),
+			"pihole_ad_blocker_status":     resourceAdBlockerStatus(),
+			"pihole_adlist":                resourceAdlist(),
+			"pihole_client":                resourceClient(),
+			"pihole_cname_record":          resourceCNAMERecord(),
+			"pihole_conditional_forwarder": resourceConditionalForwarder(),
+			"pihole_config_setting":        resourceConfigSetting(),
+			"pihole_dhcp_settings":         resourceDHCPSettings(),
+			"pihole_dhcp_static_lease":     resourceDHCPStaticLease(),
+			"pihole_dns_record":            resourceDNSRecord(),
+			"pihole_domain":                resourceDomain(),
+			"pihole_group":                 resourceGroup(),
+			"pihole_teleporter_restore":    resourceTeleporterRestore(),
+			"pihole_upstream_dns":          resourceUpstreamDNS(),
 		},
 	}
 
@@ -93,18 +216,30 @@ func configure(version string, provider *schema.Provider) func(ctx context.Conte
 			}
 		}
 
-		client, err := Config{
-			Password:       d.Get("password").(string),
-			URL:            d.Get("url").(string),
-			UserAgent:      provider.UserAgent("terraform-provider-pihole", version),
-			APIToken:       d.Get("api_token").(string),
-			CAFile:         d.Get("ca_file").(string),
-			CFServiceToken: cfServiceToken,
+		retryMaxWait, err := time.ParseDuration(d.Get("retry_max_wait").(string))
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		piholeClient, err := Config{
+			Password:          d.Get("password").(string),
+			URL:               d.Get("url").(string),
+			UserAgent:         provider.UserAgent("terraform-provider-pihole", version),
+			APIToken:          d.Get("api_token").(string),
+			TOTPSecret:        d.Get("totp_secret").(string),
+			MaxRetries:        d.Get("max_retries").(int),
+			RetryMaxWait:      retryMaxWait,
+			RequestsPerSecond: d.Get("requests_per_second").(float64),
+			Burst:             d.Get("burst").(int),
+			CAFile:            d.Get("ca_file").(string),
+			CFServiceToken:    cfServiceToken,
 		}.Client(ctx)
 		if err != nil {
 			return nil, diag.FromErr(err)
 		}
 
-		return client, diags
+		configuredClients.add(piholeClient)
+
+		return piholeClient, diags
 	}
 }
@@ -1,12 +1,45 @@
 package provider
 
 import (
+	"context"
 	"os"
+	"os/exec"
+	"sync"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole/fakepihole"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
+// testPihole is the in-process Pi-hole stand-in the acceptance tests run against when PIHOLE_URL is not set
+var testPihole *fakepihole.Server
+
+func TestMain(m *testing.M) {
+	if os.Getenv("PIHOLE_URL") != "" {
+		os.Exit(m.Run())
+	}
+
+	testPihole = fakepihole.New(fakepihole.Config{})
+
+	os.Setenv("PIHOLE_URL", testPihole.URL)                  //nolint:errcheck
+	os.Setenv("PIHOLE_PASSWORD", fakepihole.DefaultPassword) //nolint:errcheck
+	os.Unsetenv("PIHOLE_API_TOKEN")                          //nolint:errcheck
+	os.Unsetenv("PIHOLE_TOTP_SECRET")                        //nolint:errcheck
+
+	// The stand-in needs no setup, run the acceptance tests under plain go test wherever Terraform is installed
+	if _, err := exec.LookPath("terraform"); err == nil || os.Getenv("TF_ACC_TERRAFORM_PATH") != "" {
+		os.Setenv("TF_ACC", "1") //nolint:errcheck
+	}
+
+	code := m.Run()
+	testPihole.Close()
+
+	os.Exit(code)
+}
+
 func testAccPreCheck(t *testing.T) {
 	if v := os.Getenv("PIHOLE_URL"); v == "" {
 		t.Fatal("PIHOLE_URL must be set for acceptance tests")
@@ -17,6 +50,15 @@ func testAccPreCheck(t *testing.T) {
 	}
 }
 
+// testAccPreCheckLive skips acceptance tests of endpoints the Pi-hole stand-in does not serve
+func testAccPreCheckLive(t *testing.T) {
+	testAccPreCheck(t)
+
+	if testPihole != nil {
+		t.Skip("PIHOLE_URL must be set to run this acceptance test against a Pi-hole")
+	}
+}
+
 var testAccProviders map[string]*schema.Provider
 var testAccProvider *schema.Provider
 
@@ -36,3 +78,62 @@ func TestProvider(t *testing.T) {
 func TestProviderImpl(t *testing.T) {
 	var _ *schema.Provider = Provider()
 }
+
+func TestProviderSessions(t *testing.T) {
+	ctx := context.Background()
+
+	server := fakepihole.New(fakepihole.Config{})
+	defer server.Close()
+
+	provider := Provider()
+	diags := provider.Configure(ctx, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"url":      server.URL,
+		"password": fakepihole.DefaultPassword,
+	}))
+	require.False(t, diags.HasError(), diags)
+	require.Equal(t, 1, server.Sessions(), "configure")
+
+	group := provider.ResourcesMap["pihole_group"]
+	d := schema.TestResourceDataRaw(t, group.Schema, map[string]interface{}{
+		"name": "sessions",
+	})
+
+	diags = group.CreateContext(ctx, d, provider.Meta())
+	require.False(t, diags.HasError(), diags)
+	require.NotEmpty(t, d.Id())
+
+	diags = group.ReadContext(ctx, d, provider.Meta())
+	require.False(t, diags.HasError(), diags)
+
+	require.NoError(t, d.Set("description", "updated"))
+	diags = group.UpdateContext(ctx, d, provider.Meta())
+	require.False(t, diags.HasError(), diags)
+
+	groups := provider.DataSourcesMap["pihole_groups"]
+	diags = groups.ReadContext(ctx, schema.TestResourceDataRaw(t, groups.Schema, map[string]interface{}{}), provider.Meta())
+	require.False(t, diags.HasError(), diags)
+
+	diags = group.DeleteContext(ctx, d, provider.Meta())
+	require.False(t, diags.HasError(), diags)
+
+	// Concurrent operations share the session as well
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		data := schema.TestResourceDataRaw(t, groups.Schema, map[string]interface{}{})
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			diags := groups.ReadContext(ctx, data, provider.Meta())
+			assert.False(t, diags.HasError(), diags)
+		}()
+	}
+	wg.Wait()
+
+	require.Equal(t, 1, server.Logins(), "operations")
+	require.Equal(t, 1, server.Sessions(), "operations")
+
+	Shutdown(ctx)
+	require.Zero(t, server.Sessions(), "shutdown")
+}
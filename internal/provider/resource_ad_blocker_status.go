@@ -2,6 +2,9 @@ package provider
 
 import (
 	"context"
+	"fmt"
+	"math"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -15,16 +18,54 @@ func resourceAdBlockerStatus() *schema.Resource {
 		ReadContext:   resourceAdBlockerStatusRead,
 		UpdateContext: resourceAdBlockerStatusUpdate,
 		DeleteContext: resourceAdBlockerStatusDelete,
+		CustomizeDiff: resourceAdBlockerStatusCustomizeDiff,
 		Schema: map[string]*schema.Schema{
 			"enabled": {
 				Description: "Whether to enable the Pi-hole ad blocker",
 				Type:        schema.TypeBool,
 				Required:    true,
 			},
+			"timer": {
+				Description: "Duration after which Pi-hole reverts `enabled`, e.g. `10m` to disable blocking for ten minutes. Once the timer expires the reverted status is not reported as drift, any other change is.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					timer, err := time.ParseDuration(val.(string))
+					if err != nil {
+						errs = append(errs, fmt.Errorf("%s field must be a valid duration: %s", key, err))
+						return
+					}
+
+					if timer <= 0 {
+						errs = append(errs, fmt.Errorf("%s field must be a positive duration: %q", key, val.(string)))
+					}
+
+					return
+				},
+			},
+			"remaining_timer": {
+				Description: "Seconds remaining until Pi-hole reverts `enabled`, 0 when no timer is running",
+				Type:        schema.TypeInt,
+				Computed:    true,
+			},
+			"timer_expires_at": {
+				Description: "Time at which the timer set by the last apply expires, in RFC 3339 format. Empty when no timer is set",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
 		},
 	}
 }
 
+// resourceAdBlockerStatusCustomizeDiff marks the timer expiry as unknown whenever the next apply sets the blocking status
+func resourceAdBlockerStatusCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	if d.Id() != "" && (d.HasChange("enabled") || d.HasChange("timer")) {
+		return d.SetNewComputed("timer_expires_at")
+	}
+
+	return nil
+}
+
 // rresourceAdBlockerStatusCreate handles the creation a DNS record via Terraform
 func resourceAdBlockerStatusCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
 	client, ok := meta.(*pihole.Client)
@@ -32,14 +73,23 @@ func resourceAdBlockerStatusCreate(ctx context.Context, d *schema.ResourceData,
 		return diag.Errorf("Could not load client in resource request")
 	}
 
-	_, err := client.SetAdBlockEnabled(ctx, d.Get("enabled").(bool))
+	timer, err := blockingTimer(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = client.SetAdBlockEnabled(ctx, d.Get("enabled").(bool), timer)
 	if err != nil {
 		return diag.FromErr(err)
 	}
 
 	d.SetId("ad-block-enabled")
 
-	return diags
+	if err := setTimerExpiry(d, timer); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceAdBlockerStatusRead(ctx, d, meta)
 }
 
 // resourceAdBlockerStatusRead finds a DNS record based on the associated domain ID
@@ -54,7 +104,16 @@ func resourceAdBlockerStatusRead(ctx context.Context, d *schema.ResourceData, me
 		return diag.FromErr(err)
 	}
 
-	if err := d.Set("enabled", res.Enabled); err != nil {
+	enabled, err := adBlockerEnabled(d, res, time.Now())
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("enabled", enabled); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("remaining_timer", int(math.Ceil(res.Timer.Seconds()))); err != nil {
 		return diag.FromErr(err)
 	}
 
@@ -68,7 +127,12 @@ func resourceAdBlockerStatusUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.Errorf("Could not load client in resource request")
 	}
 
-	res, err := client.SetAdBlockEnabled(ctx, d.Get("enabled").(bool))
+	timer, err := blockingTimer(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	res, err := client.SetAdBlockEnabled(ctx, d.Get("enabled").(bool), timer)
 	if err != nil {
 		return diag.FromErr(err)
 	}
@@ -77,6 +141,10 @@ func resourceAdBlockerStatusUpdate(ctx context.Context, d *schema.ResourceData,
 		return diag.FromErr(err)
 	}
 
+	if err := setTimerExpiry(d, timer); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return resourceAdBlockerStatusRead(ctx, d, meta)
 }
 
@@ -86,3 +154,44 @@ func resourceAdBlockerStatusDelete(ctx context.Context, d *schema.ResourceData,
 
 	return diags
 }
+
+// blockingTimer returns the configured blocking timer, zero when unset
+func blockingTimer(d *schema.ResourceData) (time.Duration, error) {
+	timer := d.Get("timer").(string)
+	if timer == "" {
+		return 0, nil
+	}
+
+	return time.ParseDuration(timer)
+}
+
+// setTimerExpiry records when the timer set by an apply expires, clearing it when no timer is set
+func setTimerExpiry(d *schema.ResourceData, timer time.Duration) error {
+	if timer == 0 {
+		return d.Set("timer_expires_at", "")
+	}
+
+	return d.Set("timer_expires_at", time.Now().Add(timer).UTC().Format(time.RFC3339))
+}
+
+// adBlockerEnabled returns the blocking status to record in state. Pi-hole reverting the applied status once its
+// timer expired is the requested behavior and keeps the applied status, any other difference is reported as drift
+func adBlockerEnabled(d *schema.ResourceData, status *pihole.EnableAdBlock, now time.Time) (bool, error) {
+	applied := d.Get("enabled").(bool)
+
+	expiresAt := d.Get("timer_expires_at").(string)
+	if expiresAt == "" || status.Timer != 0 || status.Enabled != !applied {
+		return status.Enabled, nil
+	}
+
+	expiry, err := time.Parse(time.RFC3339, expiresAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse timer expiry %q: %w", expiresAt, err)
+	}
+
+	if now.Before(expiry) {
+		return status.Enabled, nil
+	}
+
+	return applied, nil
+}
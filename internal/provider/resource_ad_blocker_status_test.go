@@ -0,0 +1,165 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccAdBlockerStatus(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testAdBlockerStatusResourceConfig(false, "2s"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_ad_blocker_status.status", "enabled", "false"),
+					resource.TestCheckResourceAttrSet("pihole_ad_blocker_status.status", "timer_expires_at"),
+					testCheckAdBlockerStatus(false),
+				),
+			},
+			{
+				// Pi-hole re-enables blocking once the timer expires, which is not drift
+				PreConfig: func() { time.Sleep(3 * time.Second) },
+				Config:    testAdBlockerStatusResourceConfig(false, "2s"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_ad_blocker_status.status", "enabled", "false"),
+					resource.TestCheckResourceAttr("pihole_ad_blocker_status.status", "remaining_timer", "0"),
+					testCheckAdBlockerStatus(true),
+				),
+			},
+			{
+				Config: testAdBlockerStatusResourceConfig(false, "1h"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_ad_blocker_status.status", "enabled", "false"),
+					resource.TestCheckResourceAttrSet("pihole_ad_blocker_status.status", "remaining_timer"),
+					testCheckAdBlockerStatus(false),
+				),
+			},
+			{
+				// Re-enabling blocking before the timer expires is drift
+				PreConfig: func() {
+					client := testAccProvider.Meta().(*pihole.Client)
+					if _, err := client.SetAdBlockEnabled(context.Background(), true, 0); err != nil {
+						t.Fatal(err)
+					}
+				},
+				Config:             testAdBlockerStatusResourceConfig(false, "1h"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAdBlockerStatusResourceConfig(true, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_ad_blocker_status.status", "enabled", "true"),
+					resource.TestCheckResourceAttr("pihole_ad_blocker_status.status", "timer_expires_at", ""),
+					testCheckAdBlockerStatus(true),
+				),
+			},
+		},
+	})
+}
+
+func TestAdBlockerEnabled(t *testing.T) {
+	now := time.Now()
+
+	newResourceData := func(t *testing.T, enabled bool, expiresAt string) *schema.ResourceData {
+		d := schema.TestResourceDataRaw(t, resourceAdBlockerStatus().Schema, map[string]interface{}{
+			"enabled": enabled,
+		})
+		require.NoError(t, d.Set("timer_expires_at", expiresAt))
+
+		return d
+	}
+
+	t.Run("Keep the applied status once the timer expired", func(t *testing.T) {
+		t.Parallel()
+
+		d := newResourceData(t, false, now.Add(-time.Minute).Format(time.RFC3339))
+
+		enabled, err := adBlockerEnabled(d, &pihole.EnableAdBlock{Enabled: true}, now)
+		require.NoError(t, err)
+		require.False(t, enabled)
+	})
+
+	t.Run("Report a changed status before the timer expired", func(t *testing.T) {
+		t.Parallel()
+
+		d := newResourceData(t, false, now.Add(time.Minute).Format(time.RFC3339))
+
+		enabled, err := adBlockerEnabled(d, &pihole.EnableAdBlock{Enabled: true}, now)
+		require.NoError(t, err)
+		require.True(t, enabled)
+	})
+
+	t.Run("Report a changed status without a timer", func(t *testing.T) {
+		t.Parallel()
+
+		d := newResourceData(t, false, "")
+
+		enabled, err := adBlockerEnabled(d, &pihole.EnableAdBlock{Enabled: true}, now)
+		require.NoError(t, err)
+		require.True(t, enabled)
+	})
+
+	t.Run("Report a new timer set outside of Terraform", func(t *testing.T) {
+		t.Parallel()
+
+		d := newResourceData(t, false, now.Add(-time.Minute).Format(time.RFC3339))
+
+		enabled, err := adBlockerEnabled(d, &pihole.EnableAdBlock{Enabled: true, Timer: time.Minute}, now)
+		require.NoError(t, err)
+		require.True(t, enabled)
+	})
+
+	t.Run("Fail on an invalid timer expiry", func(t *testing.T) {
+		t.Parallel()
+
+		d := newResourceData(t, false, "soon")
+
+		_, err := adBlockerEnabled(d, &pihole.EnableAdBlock{Enabled: true}, now)
+		require.ErrorContains(t, err, "failed to parse timer expiry")
+	})
+}
+
+func testAdBlockerStatusResourceConfig(enabled bool, timer string) string {
+	if timer == "" {
+		return fmt.Sprintf(`
+			resource "pihole_ad_blocker_status" "status" {
+				enabled = %v
+			}
+		`, enabled)
+	}
+
+	return fmt.Sprintf(`
+		resource "pihole_ad_blocker_status" "status" {
+			enabled = %v
+			timer   = %q
+		}
+	`, enabled, timer)
+}
+
+func testCheckAdBlockerStatus(enabled bool) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testAccProvider.Meta().(*pihole.Client)
+
+		status, err := client.GetAdBlockerStatus(context.Background())
+		if err != nil {
+			return err
+		}
+
+		if status.Enabled != enabled {
+			return fmt.Errorf("expected ad blocking enabled to be %v, got %v", enabled, status.Enabled)
+		}
+
+		return nil
+	}
+}
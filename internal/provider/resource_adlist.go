@@ -0,0 +1,201 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
+)
+
+// resourceAdlist returns the Terraform resource management configuration for a Pi-hole adlist
+func resourceAdlist() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a Pi-hole blocklist or allowlist subscription",
+		CreateContext: resourceAdlistCreate,
+		ReadContext:   resourceAdlistRead,
+		UpdateContext: resourceAdlistUpdate,
+		DeleteContext: resourceAdlistDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceAdlistImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"address": {
+				Description: "URL of the list",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"type": {
+				Description: "Whether the list's domains are blocked or allowed. Must be either 'block' or 'allow'.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     pihole.AdlistTypeBlock,
+				ForceNew:    true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					adlistType := val.(string)
+
+					if adlistType != pihole.AdlistTypeBlock && adlistType != pihole.AdlistTypeAllow {
+						errs = append(errs, fmt.Errorf("%s field must be one of %v: %q", key, []string{pihole.AdlistTypeBlock, pihole.AdlistTypeAllow}, adlistType))
+					}
+
+					return
+				},
+			},
+			"comment": {
+				Description: "Comment associated with the list",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"enabled": {
+				Description: "Whether the list is enabled",
+				Type:        schema.TypeBool,
+				Default:     true,
+				Optional:    true,
+			},
+			"group_ids": {
+				Description: "IDs of the groups the list is associated with. Pi-hole assigns the default group (0) when unset.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+		},
+	}
+}
+
+// resourceAdlistCreate handles the creation of a Pi-hole adlist
+func resourceAdlistCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	adlist, err := client.CreateAdlist(ctx, &pihole.AdlistCreateRequest{
+		Address:  d.Get("address").(string),
+		Type:     d.Get("type").(string),
+		Comment:  d.Get("comment").(string),
+		Enabled:  pihole.Bool(d.Get("enabled").(bool)),
+		GroupIDs: getGroupIDs(d),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(adlistID(adlist.Type, adlist.Address))
+
+	return resourceAdlistRead(ctx, d, meta)
+}
+
+// resourceAdlistRead reads a Pi-hole adlist resource
+func resourceAdlistRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	adlistType, address, err := parseAdlistID(d.Id())
+	if err != nil {
+		// IDs of adlists created before the type was part of the ID are only the address
+		adlistType, address = d.Get("type").(string), d.Id()
+		d.SetId(adlistID(adlistType, address))
+	}
+
+	adlist, err := client.GetAdlist(ctx, address, adlistType)
+	if err != nil {
+		if _, ok := err.(*pihole.NotFoundError); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("address", adlist.Address); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("type", adlist.Type); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("comment", adlist.Comment); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("enabled", adlist.Enabled); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("group_ids", adlist.GroupIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resourceAdlistUpdate handles updates of a Pi-hole adlist
+func resourceAdlistUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	_, err := client.UpdateAdlist(ctx, &pihole.AdlistUpdateRequest{
+		Address:  d.Get("address").(string),
+		Type:     d.Get("type").(string),
+		Comment:  d.Get("comment").(string),
+		Enabled:  pihole.Bool(d.Get("enabled").(bool)),
+		GroupIDs: getGroupIDs(d),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceAdlistRead(ctx, d, meta)
+}
+
+// resourceAdlistDelete handles the deletion of a Pi-hole adlist
+func resourceAdlistDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	if err := client.DeleteAdlist(ctx, d.Get("address").(string), d.Get("type").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// resourceAdlistImport validates the type/address ID of the imported adlist
+func resourceAdlistImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	if _, _, err := parseAdlistID(d.Id()); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
+
+// adlistID returns the resource ID of an adlist, the address alone is not unique as it can be both a blocklist and
+// an allowlist
+func adlistID(adlistType string, address string) string {
+	return fmt.Sprintf("%s/%s", adlistType, address)
+}
+
+// parseAdlistID returns the type and address of an adlist resource ID
+func parseAdlistID(id string) (string, string, error) {
+	adlistType, address, ok := strings.Cut(id, "/")
+	if !ok || address == "" || (adlistType != pihole.AdlistTypeBlock && adlistType != pihole.AdlistTypeAllow) {
+		return "", "", fmt.Errorf("invalid adlist ID %q, expected <type>/<address> with type %q or %q", id, pihole.AdlistTypeBlock, pihole.AdlistTypeAllow)
+	}
+
+	return adlistType, address, nil
+}
@@ -0,0 +1,106 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
+)
+
+func TestAccAdlist(t *testing.T) {
+	address := "https://example.com/blocklist.txt"
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckLive(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckAdlistDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAdlistResourceConfig("foo", address, "comment", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_adlist.foo", "address", address),
+					resource.TestCheckResourceAttr("pihole_adlist.foo", "type", "block"),
+					resource.TestCheckResourceAttr("pihole_adlist.foo", "comment", "comment"),
+					resource.TestCheckResourceAttr("pihole_adlist.foo", "enabled", "true"),
+					resource.TestCheckResourceAttr("pihole_adlist.foo", "group_ids.#", "1"),
+					testCheckAdlistResourceExists(address, "comment", true),
+				),
+			},
+			{
+				Config: testAdlistResourceConfig("foo", address, "updated", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_adlist.foo", "comment", "updated"),
+					resource.TestCheckResourceAttr("pihole_adlist.foo", "enabled", "false"),
+					testCheckAdlistResourceExists(address, "updated", false),
+				),
+			},
+			{
+				ResourceName:      "pihole_adlist.foo",
+				ImportState:       true,
+				ImportStateId:     "block/" + address,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testAdlistResourceConfig(name, address, comment string, enabled bool) string {
+	return fmt.Sprintf(`
+		resource "pihole_adlist" %q {
+			address = %q
+			comment = %q
+			enabled = %v
+		}
+	`, name, address, comment, enabled)
+}
+
+func testCheckAdlistResourceExists(address string, comment string, enabled bool) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testAccProvider.Meta().(*pihole.Client)
+
+		adlist, err := client.GetAdlist(context.Background(), address, pihole.AdlistTypeBlock)
+		if err != nil {
+			return err
+		}
+
+		if adlist.Comment != comment {
+			return fmt.Errorf("requested adlist %s:%s does not match comment: %s", address, comment, adlist.Comment)
+		}
+
+		if adlist.Enabled != enabled {
+			return fmt.Errorf("requested adlist %s:%v does not match enabled value: %v", address, enabled, adlist.Enabled)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckAdlistDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*pihole.Client)
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "pihole_adlist" {
+			continue
+		}
+
+		adlistType, address, err := parseAdlistID(r.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if _, err := client.GetAdlist(context.Background(), address, adlistType); err != nil {
+			if _, ok := err.(*pihole.NotFoundError); !ok {
+				return err
+			}
+
+			continue
+		}
+
+		return fmt.Errorf("adlist %s still exists", r.Primary.ID)
+	}
+
+	return nil
+}
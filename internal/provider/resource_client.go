@@ -0,0 +1,179 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
+)
+
+// resourceClient returns the Terraform resource management configuration for a Pi-hole client
+func resourceClient() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a Pi-hole client and the groups it belongs to",
+		CreateContext: resourceClientCreate,
+		ReadContext:   resourceClientRead,
+		UpdateContext: resourceClientUpdate,
+		DeleteContext: resourceClientDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceClientImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"client": {
+				Description: "Client identifier. Must be an IP address, a CIDR, a MAC address, a hostname or an interface prefixed with ':' (e.g. ':eth0').",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					if _, err := pihole.NormalizeClient(val.(string)); err != nil {
+						errs = append(errs, err)
+					}
+
+					return
+				},
+				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+					oldClient, err := pihole.NormalizeClient(oldValue)
+					if err != nil {
+						return false
+					}
+
+					newClient, err := pihole.NormalizeClient(newValue)
+					if err != nil {
+						return false
+					}
+
+					return oldClient == newClient
+				},
+			},
+			"comment": {
+				Description: "Comment associated with the client",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"group_ids": {
+				Description: "IDs of the groups the client belongs to. Pi-hole assigns the default group (0) when unset.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+			"name": {
+				Description: "Hostname of the client, as resolved by Pi-hole",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// resourceClientCreate handles the creation of a Pi-hole client
+func resourceClientCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	id, err := pihole.NormalizeClient(d.Get("client").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = client.CreateClient(ctx, &pihole.GroupClientCreateRequest{
+		Client:   id,
+		Comment:  d.Get("comment").(string),
+		GroupIDs: getGroupIDs(d),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+
+	return resourceClientRead(ctx, d, meta)
+}
+
+// resourceClientRead reads a Pi-hole client resource
+func resourceClientRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	gc, err := client.GetClient(ctx, d.Id())
+	if err != nil {
+		if _, ok := err.(*pihole.NotFoundError); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("client", gc.Client); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("comment", gc.Comment); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("group_ids", gc.GroupIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("name", gc.Name); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resourceClientUpdate handles updates of a Pi-hole client
+func resourceClientUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	_, err := client.UpdateClient(ctx, &pihole.GroupClientUpdateRequest{
+		Client:   d.Get("client").(string),
+		Comment:  d.Get("comment").(string),
+		GroupIDs: getGroupIDs(d),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceClientRead(ctx, d, meta)
+}
+
+// resourceClientDelete handles the deletion of a Pi-hole client
+func resourceClientDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	if err := client.DeleteClient(ctx, d.Get("client").(string)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// resourceClientImport normalizes the imported client identifier so it matches the ID set on creation
+func resourceClientImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	id, err := pihole.NormalizeClient(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(id)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,102 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
+)
+
+func TestAccClient(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckLive(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckClientDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testClientResourceConfig("foo", "aa-bb-cc-dd-ee-ff", "comment"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_client.foo", "id", "AA:BB:CC:DD:EE:FF"),
+					resource.TestCheckResourceAttr("pihole_client.foo", "comment", "comment"),
+					resource.TestCheckResourceAttr("pihole_client.foo", "group_ids.#", "2"),
+					testCheckClientResourceExists("aa:bb:cc:dd:ee:ff", "comment", 2),
+				),
+			},
+			{
+				Config: testClientResourceConfig("foo", "AA:BB:CC:DD:EE:FF", "updated"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_client.foo", "id", "AA:BB:CC:DD:EE:FF"),
+					resource.TestCheckResourceAttr("pihole_client.foo", "comment", "updated"),
+					testCheckClientResourceExists("AA:BB:CC:DD:EE:FF", "updated", 2),
+				),
+			},
+			{
+				ResourceName:            "pihole_client.foo",
+				ImportState:             true,
+				ImportStateId:           "aa:bb:cc:dd:ee:ff",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"client"},
+			},
+		},
+	})
+}
+
+func testClientResourceConfig(name, client, comment string) string {
+	return fmt.Sprintf(`
+		resource "pihole_group" "client_group" {
+			name = "client_group"
+		}
+
+		resource "pihole_client" %q {
+			client    = %q
+			comment   = %q
+			group_ids = [0, pihole_group.client_group.id]
+		}
+	`, name, client, comment)
+}
+
+func testCheckClientResourceExists(client string, comment string, groups int) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		c := testAccProvider.Meta().(*pihole.Client)
+
+		gc, err := c.GetClient(context.Background(), client)
+		if err != nil {
+			return err
+		}
+
+		if gc.Comment != comment {
+			return fmt.Errorf("requested client %s:%s does not match comment: %s", client, comment, gc.Comment)
+		}
+
+		if len(gc.GroupIDs) != groups {
+			return fmt.Errorf("requested client %s:%d does not match group count: %d", client, groups, len(gc.GroupIDs))
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckClientDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*pihole.Client)
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "pihole_client" {
+			continue
+		}
+
+		if _, err := client.GetClient(context.Background(), r.Primary.ID); err != nil {
+			if _, ok := err.(*pihole.NotFoundError); !ok {
+				return err
+			}
+
+			continue
+		}
+
+		return fmt.Errorf("client %s still exists", r.Primary.ID)
+	}
+
+	return nil
+}
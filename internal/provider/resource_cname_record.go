@@ -2,21 +2,21 @@ package provider
 
 import (
 	"context"
-	"sync"
+	"fmt"
+	"math"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
 )
 
-var resourceDeleteMutex sync.Mutex
-
 // resourceCNAMERecord returns the CNAME Terraform resource management configuration
 func resourceCNAMERecord() *schema.Resource {
 	return &schema.Resource{
 		Description:   "Manages a Pi-hole CNAME record",
 		CreateContext: resourceCNAMERecordCreate,
 		ReadContext:   resourceCNAMERecordRead,
+		UpdateContext: resourceCNAMERecordUpdate,
 		DeleteContext: resourceCNAMERecordDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -29,10 +29,21 @@ func resourceCNAMERecord() *schema.Resource {
 				ForceNew:    true,
 			},
 			"target": {
-				Description: "Value of the CNAME record where traffic will be directed to from the configured domain value",
+				Description: "Value of the CNAME record where traffic will be directed to from the configured domain value. Changing it updates the record in place, without a resolution gap.",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
+			},
+			"ttl": {
+				Description: "Time to live of the CNAME record in seconds. The default TTL of Pi-hole is used when not set.",
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					if ttl := val.(int); ttl < 0 || ttl > math.MaxInt32 {
+						errs = append(errs, fmt.Errorf("%s field must be between 0 and %d, got: %d", key, math.MaxInt32, ttl))
+					}
+
+					return
+				},
 			},
 		},
 	}
@@ -51,6 +62,7 @@ func resourceCNAMERecordCreate(ctx context.Context, d *schema.ResourceData, meta
 	_, err := client.CreateCNAMERecord(ctx, &pihole.CNAMERecord{
 		Domain: domain,
 		Target: target,
+		TTL:    d.Get("ttl").(int),
 	})
 	if err != nil {
 		return diag.FromErr(err)
@@ -86,9 +98,32 @@ func resourceCNAMERecordRead(ctx context.Context, d *schema.ResourceData, meta i
 		return diag.FromErr(err)
 	}
 
+	if err = d.Set("ttl", record.TTL); err != nil {
+		return diag.FromErr(err)
+	}
+
 	return diags
 }
 
+// resourceCNAMERecordUpdate handles the change of the target and TTL of a CNAME record via Terraform
+func resourceCNAMERecordUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	_, err := client.UpdateCNAMERecord(ctx, &pihole.CNAMERecord{
+		Domain: d.Id(),
+		Target: d.Get("target").(string),
+		TTL:    d.Get("ttl").(int),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceCNAMERecordRead(ctx, d, meta)
+}
+
 // resourceCNAMERecordDelete handles the deletion of a CNAME record via Terraform
 func resourceCNAMERecordDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
 	client, ok := meta.(*pihole.Client)
@@ -96,9 +131,6 @@ func resourceCNAMERecordDelete(ctx context.Context, d *schema.ResourceData, meta
 		return diag.Errorf("Could not load client in resource request")
 	}
 
-	resourceDeleteMutex.Lock()
-	defer resourceDeleteMutex.Unlock()
-
 	if err := client.DeleteCNAMERecord(ctx, d.Id()); err != nil {
 		return diag.FromErr(err)
 	}
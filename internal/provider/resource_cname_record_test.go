@@ -33,6 +33,19 @@ func TestAccCNAMERecord(t *testing.T) {
 					testCheckLocalCNAMEResourceExists(t, "foo.com", "woz.com"),
 				),
 			},
+			{
+				Config: `
+					resource "pihole_cname_record" "foo" {
+						domain = "foo.com"
+						target = "woz.com"
+						ttl    = 300
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_cname_record.foo", "ttl", "300"),
+					testCheckLocalCNAMEResourceExists(t, "foo.com", "woz.com"),
+				),
+			},
 			{
 				Config: testLocalCNAMEResourceWithDataConfig(),
 				Check: resource.ComposeTestCheckFunc(
@@ -0,0 +1,168 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
+)
+
+// resourceConditionalForwarder returns the conditional forwarding Terraform resource management configuration
+func resourceConditionalForwarder() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a Pi-hole conditional forwarder, forwarding reverse lookups of a network and queries for its local domain to another DNS server",
+		CreateContext: resourceConditionalForwarderCreate,
+		ReadContext:   resourceConditionalForwarderRead,
+		DeleteContext: resourceConditionalForwarderDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceConditionalForwarderImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"cidr": {
+				Description: "Network whose reverse lookups are forwarded, e.g. `192.168.0.0/16`",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					if _, err := pihole.NormalizeCIDR(val.(string)); err != nil {
+						errs = append(errs, fmt.Errorf("%s field must be a valid CIDR: %s", key, err))
+					}
+
+					return
+				},
+				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+					oldCIDR, err := pihole.NormalizeCIDR(oldValue)
+					if err != nil {
+						return false
+					}
+
+					newCIDR, err := pihole.NormalizeCIDR(newValue)
+					if err != nil {
+						return false
+					}
+
+					return oldCIDR == newCIDR
+				},
+			},
+			"server": {
+				Description: "DNS server to forward to, as an IP address with an optional port, e.g. `192.168.0.1` or `192.168.0.1#5353`",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					if err := pihole.ValidateUpstream(val.(string)); err != nil {
+						errs = append(errs, err)
+					}
+
+					return
+				},
+			},
+			"domain": {
+				Description: "Local domain whose queries are forwarded, e.g. `lan`",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+			"active": {
+				Description: "Whether the conditional forwarder is active",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				ForceNew:    true,
+			},
+		},
+	}
+}
+
+// resourceConditionalForwarderCreate handles the creation of a conditional forwarder via Terraform
+func resourceConditionalForwarderCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	cidr, err := pihole.NormalizeCIDR(d.Get("cidr").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	_, err = client.CreateConditionalForwarder(ctx, &pihole.ConditionalForwarder{
+		Active: d.Get("active").(bool),
+		CIDR:   cidr,
+		Server: d.Get("server").(string),
+		Domain: d.Get("domain").(string),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(cidr)
+
+	return diags
+}
+
+// resourceConditionalForwarderRead finds a conditional forwarder based on the associated CIDR ID
+func resourceConditionalForwarderRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	forwarder, err := client.GetConditionalForwarder(ctx, d.Id())
+	if err != nil {
+		if _, ok := err.(*pihole.NotFoundError); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("cidr", forwarder.CIDR); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("server", forwarder.Server); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("domain", forwarder.Domain); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("active", forwarder.Active); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resourceConditionalForwarderDelete handles the deletion of a conditional forwarder via Terraform
+func resourceConditionalForwarderDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	if err := client.DeleteConditionalForwarder(ctx, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// resourceConditionalForwarderImport normalizes the imported CIDR so it matches the ID set on creation
+func resourceConditionalForwarderImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	cidr, err := pihole.NormalizeCIDR(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(cidr)
+
+	return []*schema.ResourceData{d}, nil
+}
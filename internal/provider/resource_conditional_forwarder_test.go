@@ -0,0 +1,96 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
+)
+
+func TestAccConditionalForwarder(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckConditionalForwarderDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testConditionalForwarderResourceConfig("lab", "192.168.10.0/24", "192.168.10.1", "lab"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_conditional_forwarder.lab", "id", "192.168.10.0/24"),
+					resource.TestCheckResourceAttr("pihole_conditional_forwarder.lab", "server", "192.168.10.1"),
+					resource.TestCheckResourceAttr("pihole_conditional_forwarder.lab", "domain", "lab"),
+					resource.TestCheckResourceAttr("pihole_conditional_forwarder.lab", "active", "true"),
+					testCheckConditionalForwarderResourceExists("192.168.10.0/24", "192.168.10.1", "lab"),
+				),
+			},
+			{
+				Config: testConditionalForwarderResourceConfig("lab", "192.168.10.0/24", "192.168.10.2#5353", "lab"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_conditional_forwarder.lab", "server", "192.168.10.2#5353"),
+					testCheckConditionalForwarderResourceExists("192.168.10.0/24", "192.168.10.2#5353", "lab"),
+				),
+			},
+			{
+				ResourceName:      "pihole_conditional_forwarder.lab",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testConditionalForwarderResourceConfig(name, cidr, server, domain string) string {
+	return fmt.Sprintf(`
+		resource "pihole_conditional_forwarder" %q {
+			cidr   = %q
+			server = %q
+			domain = %q
+		}
+	`, name, cidr, server, domain)
+}
+
+func testCheckConditionalForwarderResourceExists(cidr, server, domain string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testAccProvider.Meta().(*pihole.Client)
+
+		forwarder, err := client.GetConditionalForwarder(context.Background(), cidr)
+		if err != nil {
+			return err
+		}
+
+		if forwarder.Server != server {
+			return fmt.Errorf("requested %s:%s does not match server: %s", cidr, server, forwarder.Server)
+		}
+
+		if forwarder.Domain != domain {
+			return fmt.Errorf("requested %s:%s does not match domain: %s", cidr, domain, forwarder.Domain)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckConditionalForwarderDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*pihole.Client)
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "pihole_conditional_forwarder" {
+			continue
+		}
+
+		if _, err := client.GetConditionalForwarder(context.Background(), r.Primary.ID); err != nil {
+			if _, ok := err.(*pihole.NotFoundError); !ok {
+				return err
+			}
+
+			continue
+		}
+
+		return fmt.Errorf("conditional forwarder %s still exists", r.Primary.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
+)
+
+// resourceConfigSetting returns the Terraform resource management configuration for an arbitrary Pi-hole config setting
+func resourceConfigSetting() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages an arbitrary Pi-hole setting of the `/api/config` tree. Destroying the resource restores the value the setting had before it was created.",
+		CreateContext: resourceConfigSettingCreate,
+		ReadContext:   resourceConfigSettingRead,
+		UpdateContext: resourceConfigSettingUpdate,
+		DeleteContext: resourceConfigSettingDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Description: "Dotted path of the setting, e.g. `dns.blocking.mode`",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					if err := pihole.ValidateConfigPath(val.(string)); err != nil {
+						errs = append(errs, err)
+					}
+
+					return
+				},
+			},
+			"value": {
+				Description: "JSON encoded value of the setting, e.g. `jsonencode(\"NULL\")` or `jsonencode(true)`",
+				Type:        schema.TypeString,
+				Required:    true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					if _, err := normalizeJSON(val.(string)); err != nil {
+						errs = append(errs, fmt.Errorf("%s field must be valid JSON: %s", key, err))
+					}
+
+					return
+				},
+				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+					oldJSON, err := normalizeJSON(oldValue)
+					if err != nil {
+						return false
+					}
+
+					newJSON, err := normalizeJSON(newValue)
+					if err != nil {
+						return false
+					}
+
+					return oldJSON == newJSON
+				},
+			},
+			"previous_value": {
+				Description: "JSON encoded value the setting had before the resource was created, restored on destroy. Empty for imported settings, which are left unchanged on destroy.",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// normalizeJSON returns the compact form of a JSON document with object keys sorted
+func normalizeJSON(value string) (string, error) {
+	var v any
+	if err := json.Unmarshal([]byte(value), &v); err != nil {
+		return "", err
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+
+	return string(b), nil
+}
+
+// resourceConfigSettingCreate handles setting a Pi-hole config value, remembering the previous value
+func resourceConfigSettingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	path := d.Get("path").(string)
+
+	previous, err := client.GetConfigValue(ctx, path)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := client.SetConfigValue(ctx, path, json.RawMessage(d.Get("value").(string))); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(path)
+
+	normalized, err := normalizeJSON(string(previous))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("previous_value", normalized); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceConfigSettingRead(ctx, d, meta)
+}
+
+// resourceConfigSettingRead reads a Pi-hole config value
+func resourceConfigSettingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	value, err := client.GetConfigValue(ctx, d.Id())
+	if err != nil {
+		if _, ok := err.(*pihole.NotFoundError); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	normalized, err := normalizeJSON(string(value))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("path", d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	// Keep the configured formatting when the value did not drift
+	if current, err := normalizeJSON(d.Get("value").(string)); err == nil && current == normalized {
+		normalized = d.Get("value").(string)
+	}
+
+	if err = d.Set("value", normalized); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resourceConfigSettingUpdate handles updates of a Pi-hole config value
+func resourceConfigSettingUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	if err := client.SetConfigValue(ctx, d.Id(), json.RawMessage(d.Get("value").(string))); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceConfigSettingRead(ctx, d, meta)
+}
+
+// resourceConfigSettingDelete restores the value a Pi-hole config setting had before the resource was created
+func resourceConfigSettingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	if previous := d.Get("previous_value").(string); previous != "" {
+		if err := client.SetConfigValue(ctx, d.Id(), json.RawMessage(previous)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
+)
+
+func TestAccConfigSetting(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckLive(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckConfigSettingDestroy("dns.blocking.mode", `"NULL"`),
+		Steps: []resource.TestStep{
+			{
+				Config: testConfigSettingResourceConfig("mode", "dns.blocking.mode", "NXDOMAIN"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_config_setting.mode", "id", "dns.blocking.mode"),
+					resource.TestCheckResourceAttr("pihole_config_setting.mode", "value", `"NXDOMAIN"`),
+					resource.TestCheckResourceAttr("pihole_config_setting.mode", "previous_value", `"NULL"`),
+					testCheckConfigSettingValue("dns.blocking.mode", `"NXDOMAIN"`),
+				),
+			},
+			{
+				Config: testConfigSettingResourceConfig("mode", "dns.blocking.mode", "IP"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_config_setting.mode", "value", `"IP"`),
+					resource.TestCheckResourceAttr("pihole_config_setting.mode", "previous_value", `"NULL"`),
+					testCheckConfigSettingValue("dns.blocking.mode", `"IP"`),
+				),
+			},
+		},
+	})
+}
+
+func testConfigSettingResourceConfig(name, path, value string) string {
+	return fmt.Sprintf(`
+		resource "pihole_config_setting" %q {
+			path  = %q
+			value = jsonencode(%q)
+		}
+	`, name, path, value)
+}
+
+func testCheckConfigSettingValue(path, expected string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testAccProvider.Meta().(*pihole.Client)
+
+		value, err := client.GetConfigValue(context.Background(), path)
+		if err != nil {
+			return err
+		}
+
+		if string(value) != expected {
+			return fmt.Errorf("requested %s:%s does not match value: %s", path, expected, value)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckConfigSettingDestroy(path, expected string) resource.TestCheckFunc {
+	return testCheckConfigSettingValue(path, expected)
+}
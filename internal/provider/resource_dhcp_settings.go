@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
+)
+
+// resourceDHCPSettings returns the DHCP server settings Terraform resource management configuration
+func resourceDHCPSettings() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages the Pi-hole DHCP server configuration. Only one instance of this resource should be declared, destroying it restores the Pi-hole defaults which disable the DHCP server.",
+		CreateContext: resourceDHCPSettingsCreate,
+		ReadContext:   resourceDHCPSettingsRead,
+		UpdateContext: resourceDHCPSettingsUpdate,
+		DeleteContext: resourceDHCPSettingsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"active": {
+				Description: "Whether the DHCP server is enabled",
+				Type:        schema.TypeBool,
+				Required:    true,
+			},
+			"start": {
+				Description: "Start of the IPv4 range leased to clients",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"end": {
+				Description: "End of the IPv4 range leased to clients",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"router": {
+				Description: "Address of the gateway advertised to clients",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"netmask": {
+				Description: "Netmask advertised to clients. Pi-hole infers it from the interface when empty.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"lease_time": {
+				Description: "Lease time, e.g. `24h` or `infinite`. Pi-hole uses 1h for IPv4 when empty.",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"ipv6": {
+				Description: "Whether to enable IPv6 DHCP and router advertisements",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+			"rapid_commit": {
+				Description: "Whether to enable DHCPv4 rapid commit",
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+			},
+		},
+	}
+}
+
+// dhcpSettingsFromResourceData builds the DHCP settings from the resource configuration
+func dhcpSettingsFromResourceData(d *schema.ResourceData) *pihole.DHCPSettings {
+	return &pihole.DHCPSettings{
+		Active:      d.Get("active").(bool),
+		Start:       d.Get("start").(string),
+		End:         d.Get("end").(string),
+		Router:      d.Get("router").(string),
+		Netmask:     d.Get("netmask").(string),
+		LeaseTime:   d.Get("lease_time").(string),
+		IPv6:        d.Get("ipv6").(bool),
+		RapidCommit: d.Get("rapid_commit").(bool),
+	}
+}
+
+// resourceDHCPSettingsCreate handles applying the DHCP server settings via Terraform
+func resourceDHCPSettingsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	if _, err := client.UpdateDHCPSettings(ctx, dhcpSettingsFromResourceData(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("dhcp-settings")
+
+	return resourceDHCPSettingsRead(ctx, d, meta)
+}
+
+// resourceDHCPSettingsRead reads the DHCP server settings
+func resourceDHCPSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	settings, err := client.GetDHCPSettings(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	values := map[string]interface{}{
+		"active":       settings.Active,
+		"start":        settings.Start,
+		"end":          settings.End,
+		"router":       settings.Router,
+		"netmask":      settings.Netmask,
+		"lease_time":   settings.LeaseTime,
+		"ipv6":         settings.IPv6,
+		"rapid_commit": settings.RapidCommit,
+	}
+
+	for k, v := range values {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return diags
+}
+
+// resourceDHCPSettingsUpdate handles updates of the DHCP server settings via Terraform
+func resourceDHCPSettingsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	if _, err := client.UpdateDHCPSettings(ctx, dhcpSettingsFromResourceData(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceDHCPSettingsRead(ctx, d, meta)
+}
+
+// resourceDHCPSettingsDelete restores the default DHCP server settings
+func resourceDHCPSettingsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	defaults := pihole.DefaultDHCPSettings
+	if _, err := client.UpdateDHCPSettings(ctx, &defaults); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,88 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
+)
+
+func TestAccDHCPSettings(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheckLive(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDHCPSettingsDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testDHCPSettingsResourceConfig(false, "192.168.1.100", "192.168.1.200", "24h"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_dhcp_settings.dhcp", "active", "false"),
+					resource.TestCheckResourceAttr("pihole_dhcp_settings.dhcp", "start", "192.168.1.100"),
+					resource.TestCheckResourceAttr("pihole_dhcp_settings.dhcp", "end", "192.168.1.200"),
+					resource.TestCheckResourceAttr("pihole_dhcp_settings.dhcp", "lease_time", "24h"),
+					testCheckDHCPSettingsResourceExists("192.168.1.100", "192.168.1.200", "24h"),
+				),
+			},
+			{
+				Config: testDHCPSettingsResourceConfig(false, "192.168.1.50", "192.168.1.150", "12h"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_dhcp_settings.dhcp", "start", "192.168.1.50"),
+					resource.TestCheckResourceAttr("pihole_dhcp_settings.dhcp", "end", "192.168.1.150"),
+					resource.TestCheckResourceAttr("pihole_dhcp_settings.dhcp", "lease_time", "12h"),
+					testCheckDHCPSettingsResourceExists("192.168.1.50", "192.168.1.150", "12h"),
+				),
+			},
+		},
+	})
+}
+
+func testDHCPSettingsResourceConfig(active bool, start, end, leaseTime string) string {
+	return fmt.Sprintf(`
+		resource "pihole_dhcp_settings" "dhcp" {
+			active     = %v
+			start      = %q
+			end        = %q
+			router     = "192.168.1.1"
+			lease_time = %q
+		}
+	`, active, start, end, leaseTime)
+}
+
+func testCheckDHCPSettingsResourceExists(start, end, leaseTime string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testAccProvider.Meta().(*pihole.Client)
+
+		settings, err := client.GetDHCPSettings(context.Background())
+		if err != nil {
+			return err
+		}
+
+		if settings.Start != start || settings.End != end {
+			return fmt.Errorf("requested range %s-%s does not match: %s-%s", start, end, settings.Start, settings.End)
+		}
+
+		if settings.LeaseTime != leaseTime {
+			return fmt.Errorf("requested lease time %s does not match: %s", leaseTime, settings.LeaseTime)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckDHCPSettingsDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*pihole.Client)
+
+	settings, err := client.GetDHCPSettings(context.Background())
+	if err != nil {
+		return err
+	}
+
+	if *settings != pihole.DefaultDHCPSettings {
+		return fmt.Errorf("dhcp settings were not restored to their defaults: %+v", *settings)
+	}
+
+	return nil
+}
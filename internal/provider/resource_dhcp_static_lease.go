@@ -0,0 +1,152 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
+)
+
+// resourceDHCPStaticLease returns the static DHCP lease Terraform resource management configuration
+func resourceDHCPStaticLease() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages a Pi-hole static DHCP lease",
+		CreateContext: resourceDHCPStaticLeaseCreate,
+		ReadContext:   resourceDHCPStaticLeaseRead,
+		DeleteContext: resourceDHCPStaticLeaseDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceDHCPStaticLeaseImport,
+		},
+		Schema: map[string]*schema.Schema{
+			"mac": {
+				Description: "MAC address of the device",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					if _, err := pihole.NormalizeMAC(val.(string)); err != nil {
+						errs = append(errs, fmt.Errorf("%s field must be a valid MAC address: %s", key, err))
+					}
+
+					return
+				},
+				DiffSuppressFunc: func(k, oldValue, newValue string, d *schema.ResourceData) bool {
+					oldMAC, err := pihole.NormalizeMAC(oldValue)
+					if err != nil {
+						return false
+					}
+
+					newMAC, err := pihole.NormalizeMAC(newValue)
+					if err != nil {
+						return false
+					}
+
+					return oldMAC == newMAC
+				},
+			},
+			"ip": {
+				Description: "IP address to reserve for the device",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					if _, err := netip.ParseAddr(val.(string)); err != nil {
+						errs = append(errs, fmt.Errorf("%s field must be a valid IP address: %s", key, err))
+					}
+
+					return
+				},
+			},
+			"hostname": {
+				Description: "Hostname to assign to the device",
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+			},
+		},
+	}
+}
+
+// resourceDHCPStaticLeaseCreate handles the creation of a static DHCP lease via Terraform
+func resourceDHCPStaticLeaseCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	lease, err := client.CreateDHCPStaticLease(ctx, &pihole.DHCPStaticLease{
+		MAC:      d.Get("mac").(string),
+		IP:       d.Get("ip").(string),
+		Hostname: d.Get("hostname").(string),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(lease.MAC)
+
+	return diags
+}
+
+// resourceDHCPStaticLeaseRead finds a static DHCP lease based on the associated MAC address ID
+func resourceDHCPStaticLeaseRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	lease, err := client.GetDHCPStaticLease(ctx, d.Id())
+	if err != nil {
+		if _, ok := err.(*pihole.NotFoundError); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("mac", lease.MAC); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("ip", lease.IP); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("hostname", lease.Hostname); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resourceDHCPStaticLeaseDelete handles the deletion of a static DHCP lease via Terraform
+func resourceDHCPStaticLeaseDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	if err := client.DeleteDHCPStaticLease(ctx, d.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
+
+// resourceDHCPStaticLeaseImport normalizes the imported MAC address so it matches the ID set on creation
+func resourceDHCPStaticLeaseImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	mac, err := pihole.NormalizeMAC(d.Id())
+	if err != nil {
+		return nil, err
+	}
+
+	d.SetId(mac)
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,97 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
+)
+
+func TestAccDHCPStaticLease(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDHCPStaticLeaseDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testDHCPStaticLeaseResourceConfig("foo", "aa:bb:cc:dd:ee:ff", "192.168.1.20", "printer"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_dhcp_static_lease.foo", "id", "AA:BB:CC:DD:EE:FF"),
+					resource.TestCheckResourceAttr("pihole_dhcp_static_lease.foo", "ip", "192.168.1.20"),
+					resource.TestCheckResourceAttr("pihole_dhcp_static_lease.foo", "hostname", "printer"),
+					testCheckDHCPStaticLeaseResourceExists("aa:bb:cc:dd:ee:ff", "192.168.1.20", "printer"),
+				),
+			},
+			{
+				Config: testDHCPStaticLeaseResourceConfig("foo", "aa:bb:cc:dd:ee:ff", "192.168.1.21", "printer"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_dhcp_static_lease.foo", "ip", "192.168.1.21"),
+					testCheckDHCPStaticLeaseResourceExists("aa:bb:cc:dd:ee:ff", "192.168.1.21", "printer"),
+				),
+			},
+			{
+				ResourceName:            "pihole_dhcp_static_lease.foo",
+				ImportState:             true,
+				ImportStateId:           "aa-bb-cc-dd-ee-ff",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"mac"},
+			},
+		},
+	})
+}
+
+func testDHCPStaticLeaseResourceConfig(name, mac, ip, hostname string) string {
+	return fmt.Sprintf(`
+		resource "pihole_dhcp_static_lease" %q {
+			mac      = %q
+			ip       = %q
+			hostname = %q
+		}
+	`, name, mac, ip, hostname)
+}
+
+func testCheckDHCPStaticLeaseResourceExists(mac, ip, hostname string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testAccProvider.Meta().(*pihole.Client)
+
+		lease, err := client.GetDHCPStaticLease(context.Background(), mac)
+		if err != nil {
+			return err
+		}
+
+		if lease.IP != ip {
+			return fmt.Errorf("requested %s:%s does not match IP: %s", mac, ip, lease.IP)
+		}
+
+		if lease.Hostname != hostname {
+			return fmt.Errorf("requested %s:%s does not match hostname: %s", mac, hostname, lease.Hostname)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckDHCPStaticLeaseDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*pihole.Client)
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "pihole_dhcp_static_lease" {
+			continue
+		}
+
+		if _, err := client.GetDHCPStaticLease(context.Background(), r.Primary.ID); err != nil {
+			if _, ok := err.(*pihole.NotFoundError); !ok {
+				return err
+			}
+
+			continue
+		}
+
+		return fmt.Errorf("dhcp static lease %s still exists", r.Primary.ID)
+	}
+
+	return nil
+}
@@ -14,6 +14,7 @@ func resourceDNSRecord() *schema.Resource {
 		Description:   "Manages a Pi-hole DNS record",
 		CreateContext: resourceDNSRecordCreate,
 		ReadContext:   resourceDNSRecordRead,
+		UpdateContext: resourceDNSRecordUpdate,
 		DeleteContext: resourceDNSRecordDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
@@ -26,10 +27,9 @@ func resourceDNSRecord() *schema.Resource {
 				ForceNew:    true,
 			},
 			"ip": {
-				Description: "IP address to route traffic to from the DNS record domain",
+				Description: "IP address to route traffic to from the DNS record domain. Changing it updates the record in place, without a resolution gap.",
 				Type:        schema.TypeString,
 				Required:    true,
-				ForceNew:    true,
 			},
 		},
 	}
@@ -86,6 +86,24 @@ func resourceDNSRecordRead(ctx context.Context, d *schema.ResourceData, meta int
 	return diags
 }
 
+// resourceDNSRecordUpdate handles the change of the IP of a local DNS record via Terraform
+func resourceDNSRecordUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	_, err := client.UpdateDNSRecord(ctx, &pihole.DNSRecord{
+		Domain: d.Id(),
+		IP:     d.Get("ip").(string),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceDNSRecordRead(ctx, d, meta)
+}
+
 // resourceDNSRecordDelete handles the deletion of a local DNS record via Terraform
 func resourceDNSRecordDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
 	client, ok := meta.(*pihole.Client)
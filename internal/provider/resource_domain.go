@@ -0,0 +1,222 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
+)
+
+// resourceDomain returns the Terraform resource management configuration for a Pi-hole allow/deny list domain
+func resourceDomain() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages an exact or regex domain on the Pi-hole allow or deny list",
+		CreateContext: resourceDomainCreate,
+		ReadContext:   resourceDomainRead,
+		UpdateContext: resourceDomainUpdate,
+		DeleteContext: resourceDomainDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"domain": {
+				Description: "Domain, or regular expression when `kind` is `regex`",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"type": {
+				Description: "Whether the domain is on the allow or deny list. Must be either 'allow' or 'deny'.",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					domainType := val.(string)
+
+					if domainType != pihole.DomainOptionsAllow && domainType != pihole.DomainOptionsDeny {
+						errs = append(errs, fmt.Errorf("%s field must be one of %v: %q", key, []string{pihole.DomainOptionsAllow, pihole.DomainOptionsDeny}, domainType))
+					}
+
+					return
+				},
+			},
+			"kind": {
+				Description: "Whether the domain is matched exactly or as a regular expression. Must be either 'exact' or 'regex'.",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     pihole.DomainKindExact,
+				ForceNew:    true,
+				ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+					kind := val.(string)
+
+					if kind != pihole.DomainKindExact && kind != pihole.DomainKindRegex {
+						errs = append(errs, fmt.Errorf("%s field must be one of %v: %q", key, []string{pihole.DomainKindExact, pihole.DomainKindRegex}, kind))
+					}
+
+					return
+				},
+			},
+			"comment": {
+				Description: "Comment associated with the domain",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"enabled": {
+				Description: "Whether the domain rule is enabled",
+				Type:        schema.TypeBool,
+				Default:     true,
+				Optional:    true,
+			},
+			"group_ids": {
+				Description: "IDs of the groups the domain is associated with. Pi-hole assigns the default group (0) when unset.",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeInt,
+				},
+			},
+		},
+	}
+}
+
+// getGroupIDs returns the group IDs configured on the resource. It returns nil when none are set and they did not
+// change, so that Pi-hole assigns its default, and an empty slice once all groups are removed.
+func getGroupIDs(d *schema.ResourceData) []int64 {
+	set := d.Get("group_ids").(*schema.Set).List()
+	if len(set) == 0 && !d.HasChange("group_ids") {
+		return nil
+	}
+
+	ids := make([]int64, len(set))
+	for i, v := range set {
+		ids[i] = int64(v.(int))
+	}
+
+	return ids
+}
+
+// resourceDomainCreate handles the creation of a Pi-hole domain
+func resourceDomainCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	domain, err := client.CreateDomain(ctx, &pihole.DomainCreateRequest{
+		Domain:   d.Get("domain").(string),
+		Type:     d.Get("type").(string),
+		Wildcard: d.Get("kind").(string) == pihole.DomainKindRegex,
+		Comment:  d.Get("comment").(string),
+		Enabled:  pihole.Bool(d.Get("enabled").(bool)),
+		GroupIDs: getGroupIDs(d),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(strconv.FormatInt(domain.ID, 10))
+
+	return resourceDomainRead(ctx, d, meta)
+}
+
+// resourceDomainRead reads a Pi-hole domain resource
+func resourceDomainRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	domain, err := client.GetDomainByID(ctx, id)
+	if err != nil {
+		if _, ok := err.(*pihole.NotFoundError); ok {
+			d.SetId("")
+			return nil
+		}
+
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("domain", domain.Domain); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("type", domain.Type); err != nil {
+		return diag.FromErr(err)
+	}
+
+	kind := pihole.DomainKindExact
+	if domain.Wildcard {
+		kind = pihole.DomainKindRegex
+	}
+
+	if err = d.Set("kind", kind); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("comment", domain.Comment); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("enabled", domain.Enabled); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("group_ids", domain.GroupIDs); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resourceDomainUpdate handles updates of a Pi-hole domain
+func resourceDomainUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	_, err := client.UpdateDomain(ctx, &pihole.DomainUpdateRequest{
+		Domain:   d.Get("domain").(string),
+		Type:     d.Get("type").(string),
+		Wildcard: d.Get("kind").(string) == pihole.DomainKindRegex,
+		Comment:  d.Get("comment").(string),
+		Enabled:  pihole.Bool(d.Get("enabled").(bool)),
+		GroupIDs: getGroupIDs(d),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceDomainRead(ctx, d, meta)
+}
+
+// resourceDomainDelete handles the deletion of a Pi-hole domain
+func resourceDomainDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	err := client.DeleteDomain(
+		ctx,
+		d.Get("type").(string),
+		d.Get("kind").(string) == pihole.DomainKindRegex,
+		d.Get("domain").(string),
+	)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("")
+
+	return diags
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
+)
+
+func TestAccDomain(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		Providers:    testAccProviders,
+		CheckDestroy: testAccCheckDomainDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testDomainResourceConfig("foo", "ads.example.com", "deny", "exact", "comment", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_domain.foo", "domain", "ads.example.com"),
+					resource.TestCheckResourceAttr("pihole_domain.foo", "type", "deny"),
+					resource.TestCheckResourceAttr("pihole_domain.foo", "kind", "exact"),
+					resource.TestCheckResourceAttr("pihole_domain.foo", "comment", "comment"),
+					resource.TestCheckResourceAttr("pihole_domain.foo", "enabled", "true"),
+					resource.TestCheckResourceAttr("pihole_domain.foo", "group_ids.#", "1"),
+					testCheckDomainResourceExists("pihole_domain.foo", "comment", true),
+				),
+			},
+			{
+				Config: testDomainResourceConfig("foo", "ads.example.com", "deny", "exact", "updated", false),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_domain.foo", "comment", "updated"),
+					resource.TestCheckResourceAttr("pihole_domain.foo", "enabled", "false"),
+					testCheckDomainResourceExists("pihole_domain.foo", "updated", false),
+				),
+			},
+			{
+				Config: `
+					resource "pihole_domain" "foo" {
+						domain    = "ads.example.com"
+						type      = "deny"
+						kind      = "exact"
+						comment   = "updated"
+						enabled   = false
+						group_ids = []
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_domain.foo", "group_ids.#", "0"),
+					testCheckDomainResourceExists("pihole_domain.foo", "updated", false),
+				),
+			},
+			{
+				Config: testDomainResourceConfig("foo", `(\\.|^)ads\\.example\\.com$`, "allow", "regex", "updated", true),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_domain.foo", "domain", `(\.|^)ads\.example\.com$`),
+					resource.TestCheckResourceAttr("pihole_domain.foo", "type", "allow"),
+					resource.TestCheckResourceAttr("pihole_domain.foo", "kind", "regex"),
+					testCheckDomainResourceExists("pihole_domain.foo", "updated", true),
+				),
+			},
+			{
+				ResourceName:      "pihole_domain.foo",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+		},
+	})
+}
+
+func testDomainResourceConfig(name, domain, domainType, kind, comment string, enabled bool) string {
+	return fmt.Sprintf(`
+		resource "pihole_domain" %q {
+			domain  = "%s"
+			type    = %q
+			kind    = %q
+			comment = %q
+			enabled = %v
+		}
+	`, name, domain, domainType, kind, comment, enabled)
+}
+
+func testCheckDomainResourceExists(resourceName string, comment string, enabled bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		client := testAccProvider.Meta().(*pihole.Client)
+
+		r, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("resource %s not found in state", resourceName)
+		}
+
+		id, err := strconv.ParseInt(r.Primary.ID, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		domain, err := client.GetDomainByID(context.Background(), id)
+		if err != nil {
+			return err
+		}
+
+		if domain.Comment != comment {
+			return fmt.Errorf("requested domain %s:%s does not match comment: %s", domain.Domain, comment, domain.Comment)
+		}
+
+		if domain.Enabled != enabled {
+			return fmt.Errorf("requested domain %s:%v does not match enabled value: %v", domain.Domain, enabled, domain.Enabled)
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckDomainDestroy(s *terraform.State) error {
+	client := testAccProvider.Meta().(*pihole.Client)
+
+	for _, r := range s.RootModule().Resources {
+		if r.Type != "pihole_domain" {
+			continue
+		}
+
+		id, err := strconv.ParseInt(r.Primary.ID, 10, 64)
+		if err != nil {
+			return err
+		}
+
+		if _, err := client.GetDomainByID(context.Background(), id); err != nil {
+			if _, ok := err.(*pihole.NotFoundError); !ok {
+				return err
+			}
+
+			continue
+		}
+
+		return fmt.Errorf("domain %s still exists", r.Primary.ID)
+	}
+
+	return nil
+}
@@ -0,0 +1,248 @@
+package provider
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
+)
+
+// resourceTeleporterRestore returns the Terraform resource management configuration for a Teleporter archive restore
+func resourceTeleporterRestore() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Restores a Pi-hole Teleporter backup archive. The archive is uploaded again whenever its content or the import options change. An archive rewritten during an apply which did not upload it is uploaded by the next apply. Destroying the resource does not revert the restore.",
+		CreateContext: resourceTeleporterRestoreCreate,
+		ReadContext:   resourceTeleporterRestoreRead,
+		UpdateContext: resourceTeleporterRestoreUpdate,
+		DeleteContext: resourceTeleporterRestoreDelete,
+		CustomizeDiff: resourceTeleporterRestoreCustomizeDiff,
+		Schema: map[string]*schema.Schema{
+			"path": {
+				Description: "Local path of the Teleporter zip archive to restore",
+				Type:        schema.TypeString,
+				Required:    true,
+			},
+			"import": {
+				Description: "Parts of the archive to restore. Everything is restored when unset.",
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"config": {
+							Description: "Whether to restore the Pi-hole configuration (pihole.toml)",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+						},
+						"dhcp_leases": {
+							Description: "Whether to restore the DHCP leases",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+						},
+						"gravity": {
+							Description: "Whether to restore gravity database tables",
+							Type:        schema.TypeBool,
+							Optional:    true,
+							Default:     true,
+						},
+						"gravity_tables": {
+							Description: fmt.Sprintf("Gravity database tables to restore when `gravity` is enabled, all tables are restored when unset. Must be one of %v.", pihole.TeleporterGravityTables),
+							Type:        schema.TypeSet,
+							Optional:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+								ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+									table := val.(string)
+
+									for _, t := range pihole.TeleporterGravityTables {
+										if t == table {
+											return
+										}
+									}
+
+									errs = append(errs, fmt.Errorf("%s field must be one of %v: %q", key, pihole.TeleporterGravityTables, table))
+
+									return
+								},
+							},
+						},
+					},
+				},
+			},
+			"sha256": {
+				Description: "Hex encoded SHA-256 checksum of the restored archive, known after apply whenever the archive is uploaded as it may be rewritten during the apply",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"files": {
+				Description: "Files processed by Pi-hole during the last restore",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+// teleporterArchiveSHA256 returns the hex encoded SHA-256 checksum of the archive at the passed path
+func teleporterArchiveSHA256(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+
+	hash := sha256.Sum256(b)
+
+	return fmt.Sprintf("%x", hash[:]), nil
+}
+
+// teleporterImportOptions builds the import options from the resource configuration, or nil to restore everything
+func teleporterImportOptions(d *schema.ResourceData) *pihole.TeleporterImportOptions {
+	raw, ok := d.GetOk("import")
+	if !ok {
+		return nil
+	}
+
+	// The block is nil when declared without any attribute
+	importOptions, _ := raw.([]interface{})[0].(map[string]interface{})
+	if importOptions == nil {
+		return nil
+	}
+
+	options := &pihole.TeleporterImportOptions{
+		Config:     importOptions["config"].(bool),
+		DHCPLeases: importOptions["dhcp_leases"].(bool),
+	}
+
+	if !importOptions["gravity"].(bool) {
+		return options
+	}
+
+	options.GravityTables = pihole.TeleporterGravityTables
+	if tables := importOptions["gravity_tables"].(*schema.Set).List(); len(tables) > 0 {
+		options.GravityTables = make([]string, len(tables))
+		for i, t := range tables {
+			options.GravityTables[i] = t.(string)
+		}
+	}
+
+	return options
+}
+
+// resourceTeleporterRestoreCustomizeDiff plans a new upload when the content of the archive changed
+func resourceTeleporterRestoreCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, meta interface{}) error {
+	path := d.Get("path").(string)
+	if !d.NewValueKnown("path") || path == "" {
+		// The path is not known until apply
+		return teleporterRestoreSetNewComputed(d)
+	}
+
+	hash, err := teleporterArchiveSHA256(path)
+	if os.IsNotExist(err) {
+		// The archive is produced later during the apply
+		return teleporterRestoreSetNewComputed(d)
+	}
+	if err != nil {
+		return err
+	}
+
+	if d.Get("sha256").(string) == hash {
+		return nil
+	}
+
+	// Another resource may rewrite the archive during the apply, so the checksum of the uploaded archive is only
+	// known once it is uploaded
+	return teleporterRestoreSetNewComputed(d)
+}
+
+// teleporterRestoreSetNewComputed plans an upload of an archive whose content is not known yet
+func teleporterRestoreSetNewComputed(d *schema.ResourceDiff) error {
+	if err := d.SetNewComputed("sha256"); err != nil {
+		return err
+	}
+
+	return d.SetNewComputed("files")
+}
+
+// resourceTeleporterRestoreUpload uploads the configured archive and records its checksum
+func resourceTeleporterRestoreUpload(ctx context.Context, d *schema.ResourceData, client *pihole.Client) diag.Diagnostics {
+	path := d.Get("path").(string)
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	files, err := client.RestoreTeleporterBackup(ctx, filepath.Base(path), b, teleporterImportOptions(d))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	hash := sha256.Sum256(b)
+	checksum := fmt.Sprintf("%x", hash[:])
+
+	if d.Id() == "" {
+		d.SetId(checksum)
+	}
+
+	if err := d.Set("sha256", checksum); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("files", files); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// resourceTeleporterRestoreCreate handles the restore of a Teleporter archive
+func resourceTeleporterRestoreCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	if diags := resourceTeleporterRestoreUpload(ctx, d, client); diags.HasError() {
+		return diags
+	}
+
+	return resourceTeleporterRestoreRead(ctx, d, meta)
+}
+
+// resourceTeleporterRestoreRead is a no-op, Pi-hole does not keep track of restored archives
+func resourceTeleporterRestoreRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	return diags
+}
+
+// resourceTeleporterRestoreUpdate uploads the archive again when its content or the import options changed
+func resourceTeleporterRestoreUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	if d.HasChanges("path", "import", "sha256") {
+		if diags := resourceTeleporterRestoreUpload(ctx, d, client); diags.HasError() {
+			return diags
+		}
+	}
+
+	return resourceTeleporterRestoreRead(ctx, d, meta)
+}
+
+// resourceTeleporterRestoreDelete removes the restore from the state, the restored configuration is left in place
+func resourceTeleporterRestoreDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	d.SetId("")
+
+	return diags
+}
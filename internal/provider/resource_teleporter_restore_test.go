@@ -0,0 +1,91 @@
+package provider
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccTeleporterRestore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "pihole.zip")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheckLive(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testTeleporterRestoreResourceConfig(path, `
+					import {
+					  config      = false
+					  dhcp_leases = false
+					  gravity_tables = ["group", "adlist"]
+					}
+				`),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("pihole_teleporter_restore.restore", "sha256", "data.pihole_teleporter_backup.backup", "sha256"),
+					resource.TestCheckResourceAttrSet("pihole_teleporter_restore.restore", "files.0"),
+				),
+			},
+			{
+				Config: testTeleporterRestoreResourceConfig(path, ""),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_teleporter_restore.restore", "import.#", "0"),
+					resource.TestCheckResourceAttrSet("pihole_teleporter_restore.restore", "files.0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccTeleporterRestoreArchiveProducedDuringApply(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backups", "pihole.zip")
+
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheckLive(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				// The backup depends on a group created in the same apply, so the archive does not exist during plan
+				Config: fmt.Sprintf(`
+					resource "pihole_group" "restore" {
+					  name = "teleporter-restore"
+					}
+
+					data "pihole_teleporter_backup" "backup" {
+					  path = %q
+
+					  depends_on = [pihole_group.restore]
+					}
+
+					resource "pihole_teleporter_restore" "restore" {
+					  path = data.pihole_teleporter_backup.backup.path
+
+					  import {
+					    config      = false
+					    dhcp_leases = false
+					    gravity     = false
+					  }
+					}
+				`, path),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair("pihole_teleporter_restore.restore", "sha256", "data.pihole_teleporter_backup.backup", "sha256"),
+				),
+			},
+		},
+	})
+}
+
+func testTeleporterRestoreResourceConfig(path, importBlock string) string {
+	return fmt.Sprintf(`
+		data "pihole_teleporter_backup" "backup" {
+		  path = %q
+		}
+
+		resource "pihole_teleporter_restore" "restore" {
+		  path = data.pihole_teleporter_backup.backup.path
+		  %s
+		}
+	`, path, importBlock)
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
+)
+
+// resourceUpstreamDNS returns the upstream DNS servers Terraform resource management configuration
+func resourceUpstreamDNS() *schema.Resource {
+	return &schema.Resource{
+		Description:   "Manages the ordered list of Pi-hole upstream DNS servers. Only one instance of this resource should be declared, destroying it leaves the configured upstreams in place.",
+		CreateContext: resourceUpstreamDNSCreate,
+		ReadContext:   resourceUpstreamDNSRead,
+		UpdateContext: resourceUpstreamDNSUpdate,
+		DeleteContext: resourceUpstreamDNSDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"upstreams": {
+				Description: "Ordered list of upstream DNS servers, as an IP address with an optional port, e.g. `9.9.9.9` or `127.0.0.1#5335`",
+				Type:        schema.TypeList,
+				Required:    true,
+				MinItems:    1,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+					ValidateFunc: func(val interface{}, key string) (warns []string, errs []error) {
+						if err := pihole.ValidateUpstream(val.(string)); err != nil {
+							errs = append(errs, err)
+						}
+
+						return
+					},
+				},
+			},
+		},
+	}
+}
+
+// upstreamsFromResourceData returns the configured upstream DNS servers
+func upstreamsFromResourceData(d *schema.ResourceData) []string {
+	raw := d.Get("upstreams").([]interface{})
+	upstreams := make([]string, len(raw))
+
+	for i, u := range raw {
+		upstreams[i] = u.(string)
+	}
+
+	return upstreams
+}
+
+// resourceUpstreamDNSCreate handles setting the upstream DNS servers via Terraform
+func resourceUpstreamDNSCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	if _, err := client.SetUpstreams(ctx, upstreamsFromResourceData(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("upstream-dns")
+
+	return resourceUpstreamDNSRead(ctx, d, meta)
+}
+
+// resourceUpstreamDNSRead reads the upstream DNS servers
+func resourceUpstreamDNSRead(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	upstreams, err := client.ListUpstreams(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := d.Set("upstreams", upstreams); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// resourceUpstreamDNSUpdate handles updates of the upstream DNS servers via Terraform
+func resourceUpstreamDNSUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	client, ok := meta.(*pihole.Client)
+	if !ok {
+		return diag.Errorf("Could not load client in resource request")
+	}
+
+	if _, err := client.SetUpstreams(ctx, upstreamsFromResourceData(d)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceUpstreamDNSRead(ctx, d, meta)
+}
+
+// resourceUpstreamDNSDelete removes the upstream DNS servers from the Terraform state, leaving them configured so Pi-hole keeps resolving
+func resourceUpstreamDNSDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) (diags diag.Diagnostics) {
+	d.SetId("")
+
+	return diags
+}
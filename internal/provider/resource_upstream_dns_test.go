@@ -0,0 +1,64 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/ryanwholey/terraform-provider-pihole/internal/pihole"
+)
+
+func TestAccUpstreamDNS(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		PreCheck:  func() { testAccPreCheck(t) },
+		Providers: testAccProviders,
+		Steps: []resource.TestStep{
+			{
+				Config: testUpstreamDNSResourceConfig("9.9.9.9", "127.0.0.1#5335"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_upstream_dns.upstreams", "upstreams.#", "2"),
+					resource.TestCheckResourceAttr("pihole_upstream_dns.upstreams", "upstreams.0", "9.9.9.9"),
+					resource.TestCheckResourceAttr("pihole_upstream_dns.upstreams", "upstreams.1", "127.0.0.1#5335"),
+					testCheckUpstreamDNSResourceExists("9.9.9.9", "127.0.0.1#5335"),
+				),
+			},
+			{
+				Config: testUpstreamDNSResourceConfig("127.0.0.1#5335", "9.9.9.9"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("pihole_upstream_dns.upstreams", "upstreams.0", "127.0.0.1#5335"),
+					resource.TestCheckResourceAttr("pihole_upstream_dns.upstreams", "upstreams.1", "9.9.9.9"),
+					testCheckUpstreamDNSResourceExists("127.0.0.1#5335", "9.9.9.9"),
+				),
+			},
+		},
+	})
+}
+
+func testUpstreamDNSResourceConfig(upstreams ...string) string {
+	return fmt.Sprintf(`
+		resource "pihole_upstream_dns" "upstreams" {
+			upstreams = ["%s"]
+		}
+	`, strings.Join(upstreams, `", "`))
+}
+
+func testCheckUpstreamDNSResourceExists(upstreams ...string) resource.TestCheckFunc {
+	return func(*terraform.State) error {
+		client := testAccProvider.Meta().(*pihole.Client)
+
+		actual, err := client.ListUpstreams(context.Background())
+		if err != nil {
+			return err
+		}
+
+		if !reflect.DeepEqual(actual, upstreams) {
+			return fmt.Errorf("requested upstreams %v do not match: %v", upstreams, actual)
+		}
+
+		return nil
+	}
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"context"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
 	"github.com/ryanwholey/terraform-provider-pihole/internal/provider"
 )
@@ -9,4 +11,7 @@ func main() {
 	plugin.Serve(&plugin.ServeOpts{
 		ProviderFunc: provider.Provider,
 	})
+
+	// Serve returns once Terraform shut the provider down gracefully
+	provider.Shutdown(context.Background())
 }